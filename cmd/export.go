@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/drip/beyond/config"
+	"github.com/drip/beyond/pkg/db"
+	"github.com/drip/beyond/pkg/export"
+
+	flag "github.com/jessevdk/go-flags"
+)
+
+type exportOptions struct {
+	Format    string `long:"format" description:"output format" choice:"csv" choice:"jsonl" choice:"parquet" default:"csv"`
+	Output    string `short:"o" long:"output" description:"output file, stdout by default"`
+	DSN       string `long:"database" description:"database DSN, the one of the config by default"`
+	Pair      string `long:"pair" description:"actions of this pair, e.g. QLC/QGAS"`
+	Since     string `long:"since" description:"actions created at or after, e.g. 2020-10-01 or 2020-10-01T08:00:00Z"`
+	Until     string `long:"until" description:"actions created before"`
+	MinProfit string `long:"min-profit" description:"actions with at least this profit"`
+	MaxProfit string `long:"max-profit" description:"actions with at most this profit"`
+	Sake      string `long:"include-sake" description:"actions with or without sake" choice:"true" choice:"false"`
+	TxHash    string `long:"has-tx-hash" description:"actions with or without a transaction" choice:"true" choice:"false"`
+	Status    string `long:"status" description:"actions whose transaction has this status" choice:"pending" choice:"confirmed" choice:"failed" choice:"dropped" choice:"unknown"`
+	SortBy    string `long:"sort" description:"order of the actions" choice:"time" choice:"profit" choice:"gas" default:"time"`
+	Ascending bool   `long:"ascending" description:"oldest or smallest first"`
+}
+
+// exportActions writes the actions matching the filters to a file, e.g.
+// gbeyond export --format parquet --since 2020-01-01 --until 2021-01-01 -o 2020.parquet
+func exportActions(args []string) int {
+	opts := &exportOptions{}
+	parser := flag.NewParser(opts, flag.Default)
+	parser.Usage = "export [OPTIONS]"
+	if _, err := parser.ParseArgs(args); err != nil {
+		if fe, ok := err.(*flag.Error); ok && fe.Type == flag.ErrHelp {
+			return 0
+		}
+		return 1
+	}
+	q, err := opts.query()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	cfg := &config.Config{DatabaseDSN: opts.DSN}
+	if err := cfg.Load(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	dbOpts, err := dbOptions(cfg.DB)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	database, err := db.NewDB(cfg.Database(), dbOpts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if sqlDB, err := database.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	var w io.Writer = os.Stdout
+	if opts.Output != "" {
+		f, err := os.Create(opts.Output)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer f.Close()
+		w = f
+	}
+	n, err := export.Actions(context.Background(), database, q, opts.Format, w)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		if opts.Output != "" {
+			os.Remove(opts.Output)
+		}
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "exported %d actions\n", n)
+	return 0
+}
+
+func (o *exportOptions) query() (*db.ActionQuery, error) {
+	q := &db.ActionQuery{Pair: o.Pair, Status: o.Status, SortBy: o.SortBy, Ascending: o.Ascending}
+	var err error
+	if q.Since, err = parseTime(o.Since); err != nil {
+		return nil, err
+	}
+	if q.Until, err = parseTime(o.Until); err != nil {
+		return nil, err
+	}
+	if q.MinProfit, err = parseDecimal(o.MinProfit); err != nil {
+		return nil, err
+	}
+	if q.MaxProfit, err = parseDecimal(o.MaxProfit); err != nil {
+		return nil, err
+	}
+	if o.Sake != "" {
+		v := o.Sake == "true"
+		q.IncludeSake = &v
+	}
+	if o.TxHash != "" {
+		v := o.TxHash == "true"
+		q.HasTxHash = &v
+	}
+	return q, nil
+}
+
+// parseTime parses a date or an RFC 3339 time, dates are UTC.
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %s", s)
+	}
+	return t, nil
+}
+
+func parseDecimal(s string) (*db.Decimal, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, err := db.ParseDecimal(s)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
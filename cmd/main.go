@@ -3,12 +3,17 @@ package main
 import (
 	"fmt"
 	"github.com/drip/beyond/config"
+	"github.com/drip/beyond/pkg/db"
+	"github.com/drip/beyond/pkg/health"
 	"github.com/drip/beyond/pkg/log"
+	"github.com/drip/beyond/pkg/tracker"
 	"github.com/drip/beyond/pkg/util"
 	"github.com/drip/beyond/rpc/grpc"
 	"github.com/drip/beyond/rpc/jsonrpc"
 	"os"
 	"os/signal"
+	"reflect"
+	"sort"
 	"syscall"
 
 	flag "github.com/jessevdk/go-flags"
@@ -23,6 +28,17 @@ var (
 var cfg = &config.Config{}
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			os.Exit(migrate(os.Args[2:]))
+		case "export":
+			os.Exit(exportActions(os.Args[2:]))
+		case "restore":
+			os.Exit(restore(os.Args[2:]))
+		}
+	}
+
 	fmt.Println("start...")
 
 	if _, err := flag.ParseArgs(cfg, os.Args); err != nil {
@@ -42,7 +58,7 @@ func main() {
 	}
 
 	if err := cfg.Verify(); err != nil {
-		fmt.Println(util.ToIndentString(cfg))
+		fmt.Println(printable(cfg))
 		log.Root.Fatal(err)
 	}
 	if err := cfg.Save(); err != nil {
@@ -55,23 +71,157 @@ func main() {
 	_ = log.Setup(cfg.LogDir(), cfg.LogLevel)
 
 	logger := log.NewLogger("main")
-	logger.Info(util.ToIndentString(cfg))
+	logger.Info(printable(cfg))
 
-	grpcServer := grpc.NewServer(cfg)
-	if err := grpcServer.Start(); err != nil {
+	dbOpts, err := dbOptions(cfg.DB)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	database, err := db.NewDB(cfg.Database(), dbOpts)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		logger.Fatal(err)
+	}
+	defer sqlDB.Close()
+	var batchSize, queueSize int
+	if cfg.DB != nil {
+		batchSize, queueSize = cfg.DB.WriteBatchSize, cfg.DB.WriteQueueSize
+	}
+	writer := db.NewWriter(database, batchSize, queueSize)
+	writer.Start()
+	var backups *db.BackupScheduler
+	if database.Dialector.Name() == db.DriverSQLite {
+		interval, err := cfg.Backup.Durations()
+		if err != nil {
+			logger.Fatal(err)
+		}
+		opts := &db.BackupOptions{Dir: cfg.BackupDir()}
+		if cfg.Backup != nil {
+			opts.Keep, opts.Compress = cfg.Backup.Keep, cfg.Backup.Compress
+		}
+		backups = db.NewBackupScheduler(database, opts, interval)
+		backups.Start()
+	} else if cfg.Backup != nil {
+		logger.Warnf("backups of %s databases aren't supported", database.Dialector.Name())
+	}
+
+	monitor := health.NewMonitor(health.DefaultInterval, health.DefaultTimeout)
+	grpcServer, err := grpc.NewServer(cfg, database)
+	if err != nil {
 		logger.Fatal(err)
 	}
+	grpcServer.HandleProbe("/healthz", monitor.Handler(false))
+	grpcServer.HandleProbe("/readyz", monitor.Handler(true))
 
-	if jsonrpcService, err := jsonrpc.NewRPCService(cfg); err != nil {
+	jsonrpcService, err := jsonrpc.NewRPCService(cfg)
+	if err != nil {
 		logger.Fatal(err)
-	}else {
-		if err := jsonrpcService.Start(); err != nil {
+	}
+	// serve the gRPC services over JSON-RPC too
+	grpcApis, err := grpcServer.JSONRPCApis()
+	if err != nil {
+		logger.Fatal(err)
+	}
+	jsonrpcService.RegisterApis(grpcApis...)
+	jsonrpcService.SetDatabase(writer)
+	writer.SetActionHook(grpcServer.PublishAction)
+	jsonrpcService.SetBackups(backups)
+	if err := jsonrpcService.Start(); err != nil {
+		logger.Fatal(err)
+	}
+	if path, handler := jsonrpcService.GatewayHandler(); handler != nil {
+		grpcServer.Handle(path, handler)
+	}
+
+	if err := grpcServer.Start(); err != nil {
+		logger.Fatal(err)
+	}
+
+	var txTracker *tracker.Tracker
+	if cfg.Tracker == nil || !cfg.Tracker.Disabled {
+		interval, maxBackoff, dropAfter, timeout, err := cfg.Tracker.Durations()
+		if err != nil {
 			logger.Fatal(err)
 		}
+		txTracker = tracker.New(writer, tracker.NewQLCChain(cfg.Endpoint), &tracker.Options{
+			Interval:   interval,
+			MaxBackoff: maxBackoff,
+			DropAfter:  dropAfter,
+			Timeout:    timeout,
+		}, grpcServer.PublishTransaction)
+		txTracker.Start()
 	}
 
+	monitor.Register("qlc", false, health.QLCCheck(cfg.Endpoint))
+	monitor.Register("database", true, health.PingCheck(sqlDB))
+	grpcEndpoints := grpcServer.Endpoints()
+	for _, name := range sortedKeys(grpcEndpoints) {
+		network, address, err := util.Scheme(grpcEndpoints[name])
+		if err != nil {
+			logger.Fatal(err)
+		}
+		monitor.Register("listener/"+name, true, health.DialCheck(network, address))
+	}
+	rpcEndpoints := jsonrpcService.Endpoints()
+	for _, name := range sortedKeys(rpcEndpoints) {
+		addr := rpcEndpoints[name]
+		monitor.Register("listener/"+name, true, health.DialCheck(addr.Network(), addr.String()))
+	}
+	grpcServer.SetHealth(monitor)
+	monitor.Start()
+
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 	<-c
 
+	monitor.Stop()
+	if txTracker != nil {
+		txTracker.Stop()
+	}
+	jsonrpcService.Stop()
+	grpcServer.Stop()
+	writer.Stop()
+	if backups != nil {
+		backups.Stop()
+	}
+}
+
+// dbOptions returns the connection options of the database, the defaults if cfg is nil.
+func dbOptions(cfg *config.DBCfg) (*db.Options, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	busyTimeout, maxLifetime, maxIdleTime, err := cfg.Durations()
+	if err != nil {
+		return nil, err
+	}
+	return &db.Options{
+		JournalMode:     cfg.JournalMode,
+		Synchronous:     cfg.Synchronous,
+		BusyTimeout:     busyTimeout,
+		MaxOpenConns:    cfg.MaxOpenConns,
+		MaxIdleConns:    cfg.MaxIdleConns,
+		ConnMaxLifetime: maxLifetime,
+		ConnMaxIdleTime: maxIdleTime,
+	}, nil
+}
+
+// sortedKeys returns the keys of a map with string keys in order.
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	for _, k := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// printable formats cfg for logging, the credentials are masked.
+func printable(cfg *config.Config) string {
+	r := cfg.Redacted()
+	r.DatabaseDSN = db.RedactDSN(r.DatabaseDSN)
+	return util.ToIndentString(r)
 }
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/drip/beyond/config"
+	"github.com/drip/beyond/pkg/db"
+
+	flag "github.com/jessevdk/go-flags"
+)
+
+type migrateOptions struct {
+	To     int    `long:"to" description:"schema version to migrate to, the latest by default" default:"-1"`
+	DryRun bool   `long:"dry-run" description:"print the migrations without applying them"`
+	DSN    string `long:"database" description:"database DSN, the one of the config by default"`
+}
+
+// migrate runs the schema migrations of the actions database, e.g.
+// gbeyond migrate --to 2 --dry-run
+func migrate(args []string) int {
+	opts := &migrateOptions{}
+	parser := flag.NewParser(opts, flag.Default)
+	parser.Usage = "migrate [OPTIONS]"
+	if _, err := parser.ParseArgs(args); err != nil {
+		if fe, ok := err.(*flag.Error); ok && fe.Type == flag.ErrHelp {
+			return 0
+		}
+		return 1
+	}
+
+	cfg := &config.Config{DatabaseDSN: opts.DSN}
+	if err := cfg.Load(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	dbOpts, err := dbOptions(cfg.DB)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	database, err := db.Open(cfg.Database(), dbOpts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if sqlDB, err := database.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	plan, err := db.Migrate(database, opts.To, opts.DryRun)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Printf("%s database: schema version %d, latest %d\n", database.Dialector.Name(), plan.From, db.LatestVersion())
+	if len(plan.Steps) == 0 {
+		fmt.Println("nothing to migrate")
+		return 0
+	}
+	direction := "up"
+	if plan.Down() {
+		direction = "down"
+	}
+	for _, m := range plan.Steps {
+		if opts.DryRun {
+			fmt.Printf("would migrate %s %s\n", direction, m)
+		} else {
+			fmt.Printf("migrated %s %s\n", direction, m)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/drip/beyond/config"
+	"github.com/drip/beyond/pkg/db"
+
+	flag "github.com/jessevdk/go-flags"
+)
+
+type restoreOptions struct {
+	Latest bool   `long:"latest" description:"restore the latest backup of the backup dir"`
+	DSN    string `long:"database" description:"database DSN, the one of the config by default"`
+}
+
+// restore replaces the SQLite actions database with a backup, e.g.
+// gbeyond restore ~/.gbeyond/backup/actions-20201019T152300.000Z.db.gz
+// The daemon must be stopped.
+func restore(args []string) int {
+	opts := &restoreOptions{}
+	parser := flag.NewParser(opts, flag.Default)
+	parser.Usage = "restore [OPTIONS] [BACKUP]"
+	args, err := parser.ParseArgs(args)
+	if err != nil {
+		if fe, ok := err.(*flag.Error); ok && fe.Type == flag.ErrHelp {
+			return 0
+		}
+		return 1
+	}
+	if len(args) > 1 || (len(args) == 1) == opts.Latest {
+		fmt.Fprintln(os.Stderr, "give either a backup or --latest")
+		return 1
+	}
+
+	cfg := &config.Config{DatabaseDSN: opts.DSN}
+	if err := cfg.Load(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	file, err := databaseFile(cfg.Database())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	var backup string
+	if opts.Latest {
+		backups, err := db.ListBackups(cfg.BackupDir(), file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		if len(backups) == 0 {
+			fmt.Fprintf(os.Stderr, "no backups in %s\n", cfg.BackupDir())
+			return 1
+		}
+		backup = backups[0].Path
+	} else {
+		backup = args[0]
+	}
+
+	_, statErr := os.Stat(file)
+	if err := db.Restore(backup, file); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Printf("restored %s to %s\n", backup, file)
+	if statErr == nil {
+		fmt.Printf("the previous database is kept as %s\n", file+db.RestoreSuffix)
+	}
+	return 0
+}
+
+// databaseFile returns the file of a SQLite DSN.
+func databaseFile(dsn string) (string, error) {
+	driver, source, err := db.ParseDSN(dsn)
+	if err != nil {
+		return "", err
+	}
+	if driver != db.DriverSQLite {
+		return "", errors.New("only SQLite databases can be restored, use the tools of the server")
+	}
+	if i := strings.IndexByte(source, '?'); i >= 0 {
+		source = source[:i]
+	}
+	return strings.TrimPrefix(source, "file:"), nil
+}
@@ -1,13 +1,19 @@
 package config
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/drip/beyond/pkg/util"
 	"io/ioutil"
 	"os"
 	"os/user"
 	"path/filepath"
 	"runtime"
+	"strconv"
+	"strings"
 	"time"
 
 	"gopkg.in/validator.v2"
@@ -25,14 +31,345 @@ type Config struct {
 	Endpoint string   `json:"endpoint" long:"endpoint" description:"endpoint" default:"ws://127.0.0.1:29736"`
 	GRPCCfg  *GRPCCfg `json:"grpc" validate:"nonnil"`
 	RPCCfg   *RPCCfg  `json:"rpc" validate:"nonnil"`
+	// DSN of the actions database with a driver scheme, sqlite://, postgres:// or mysql://
+	DatabaseDSN string `json:"database" long:"database" description:"database DSN, actions.db in the data dir by default"`
+	// Connections to the actions database
+	DB *DBCfg `json:"db"`
+	// Backups of the actions database
+	Backup *BackupCfg `json:"backup"`
+	// Tracking of the transactions of the swaps
+	Tracker *TrackerCfg `json:"tracker"`
 }
 
 type GRPCCfg struct {
 	// TCP or UNIX socket address for the RPC server to listen on
 	ListenAddress string `json:"listenAddress" long:"listenAddress" description:"RPC server listen address" default:"tcp://0.0.0.0:29705"`
-	// TCP or UNIX socket address for the gRPC server to listen on
+	// TCP or UNIX socket address for the gRPC server to listen on. gRPC and the gateway
+	// share one port if it equals the gateway address.
 	GRPCListenAddress  string   `json:"gRPCListenAddress" long:"grpcAddress" description:"GRPC server listen address" default:"tcp://0.0.0.0:29706"`
 	CORSAllowedOrigins []string `json:"allowedOrigins" long:"allowedOrigins" description:"AllowedOrigins of CORS" default:"*"`
+	// Disable the gRPC listener, the gateway and JSON-RPC call the services in process
+	GRPCDisabled bool `json:"gRPCDisabled"`
+	// Disable the RESTful gateway, the listen address then serves the /healthz and
+	// /readyz probes only
+	GatewayDisabled bool `json:"gatewayDisabled"`
+	// Permissions of the listeners bound to unix:// addresses
+	Socket *SocketCfg `json:"unixSocket"`
+	// Server-side deadlines of gRPC calls
+	Deadlines *DeadlineCfg `json:"deadlines"`
+	// Transport limits of the gRPC server, also used by the gateway's connection to it
+	Server *ServerCfg `json:"server"`
+}
+
+// DeadlineCfg limits the time gRPC calls may run. Durations are given like 30s or 5m, an
+// empty duration sets no limit.
+type DeadlineCfg struct {
+	// Deadline of unary calls which arrive without one
+	Default string `json:"default"`
+	// Longest deadline of unary calls, later client deadlines are shortened
+	Max string `json:"max"`
+	// Deadlines by full method name, e.g. /proto.PingAPI/Status, or service prefix, e.g.
+	// /proto.EventAPI/. They apply to streams too and take precedence over max.
+	Methods map[string]string `json:"methods"`
+}
+
+// Durations parses the deadlines.
+func (d *DeadlineCfg) Durations() (def, max time.Duration, methods map[string]time.Duration, err error) {
+	methods = make(map[string]time.Duration)
+	if d == nil {
+		return 0, 0, methods, nil
+	}
+	parse := func(name, s string) (time.Duration, error) {
+		return parseDuration(name+" deadline", s)
+	}
+	if def, err = parse("default", d.Default); err != nil {
+		return
+	}
+	if max, err = parse("max", d.Max); err != nil {
+		return
+	}
+	if def > 0 && max > 0 && def > max {
+		return 0, 0, nil, fmt.Errorf("default deadline %s exceeds max deadline %s", d.Default, d.Max)
+	}
+	for method, s := range d.Methods {
+		if !strings.HasPrefix(method, "/") {
+			return 0, 0, nil, fmt.Errorf("deadline method %s must start with /", method)
+		}
+		v, err := parse(method, s)
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		methods[method] = v
+	}
+	return def, max, methods, nil
+}
+
+// ServerCfg tunes the gRPC transport. Zero values keep the gRPC defaults, e.g. messages
+// of at most 4MB are received and idle connections are never closed.
+type ServerCfg struct {
+	// Largest message in bytes the server receives
+	MaxRecvMsgSize int `json:"maxRecvMsgSize"`
+	// Largest message in bytes the server sends, e.g. a page of the action history
+	MaxSendMsgSize int `json:"maxSendMsgSize"`
+	// Concurrent streams per client connection
+	MaxConcurrentStreams uint32 `json:"maxConcurrentStreams"`
+	// Time to establish new connections, including the HTTP/2 handshake
+	ConnectionTimeout string        `json:"connectionTimeout"`
+	Keepalive         *KeepaliveCfg `json:"keepalive"`
+}
+
+// KeepaliveCfg configures the keepalive pings of the server and the pings it accepts.
+type KeepaliveCfg struct {
+	// Ping clients after this time without activity
+	Time string `json:"time"`
+	// Close the connection if a ping is not answered in time
+	Timeout string `json:"timeout"`
+	// Close connections idle for this long
+	MaxConnectionIdle string `json:"maxConnectionIdle"`
+	// Close connections after this time, so clients rebalance
+	MaxConnectionAge string `json:"maxConnectionAge"`
+	// Time calls get to finish after MaxConnectionAge
+	MaxConnectionAgeGrace string `json:"maxConnectionAgeGrace"`
+	// Shortest interval of client pings, clients pinging more often are disconnected.
+	// Defaults to Time if it is set, to the gRPC default of 5m otherwise.
+	MinTime string `json:"minTime"`
+	// Accept client pings without active streams
+	PermitWithoutStream bool `json:"permitWithoutStream"`
+}
+
+// Keepalive holds the parsed keepalive settings.
+type Keepalive struct {
+	Time                  time.Duration
+	Timeout               time.Duration
+	MaxConnectionIdle     time.Duration
+	MaxConnectionAge      time.Duration
+	MaxConnectionAgeGrace time.Duration
+	MinTime               time.Duration
+	PermitWithoutStream   bool
+}
+
+// ConnTimeout parses the connection timeout, zero keeps the gRPC default.
+func (s *ServerCfg) ConnTimeout() (time.Duration, error) {
+	if s == nil {
+		return 0, nil
+	}
+	return parseDuration("connection timeout", s.ConnectionTimeout)
+}
+
+// Verify checks the limits and durations of the settings.
+func (s *ServerCfg) Verify() error {
+	if s == nil {
+		return nil
+	}
+	if s.MaxRecvMsgSize < 0 {
+		return fmt.Errorf("invalid max receive message size %d", s.MaxRecvMsgSize)
+	}
+	if s.MaxSendMsgSize < 0 {
+		return fmt.Errorf("invalid max send message size %d", s.MaxSendMsgSize)
+	}
+	if _, err := s.ConnTimeout(); err != nil {
+		return err
+	}
+	_, err := s.Keepalive.Params()
+	return err
+}
+
+// Params parses the keepalive settings, a nil config yields nil.
+func (k *KeepaliveCfg) Params() (*Keepalive, error) {
+	if k == nil {
+		return nil, nil
+	}
+	p := &Keepalive{PermitWithoutStream: k.PermitWithoutStream}
+	for _, d := range []struct {
+		name  string
+		value string
+		to    *time.Duration
+	}{
+		{"keepalive time", k.Time, &p.Time},
+		{"keepalive timeout", k.Timeout, &p.Timeout},
+		{"max connection idle", k.MaxConnectionIdle, &p.MaxConnectionIdle},
+		{"max connection age", k.MaxConnectionAge, &p.MaxConnectionAge},
+		{"max connection age grace", k.MaxConnectionAgeGrace, &p.MaxConnectionAgeGrace},
+		{"keepalive min time", k.MinTime, &p.MinTime},
+	} {
+		v, err := parseDuration(d.name, d.value)
+		if err != nil {
+			return nil, err
+		}
+		*d.to = v
+	}
+	if p.Time > 0 && p.Time < time.Second {
+		return nil, fmt.Errorf("keepalive time %s is below 1s", k.Time)
+	}
+	if p.MaxConnectionAgeGrace > 0 && p.MaxConnectionAge == 0 {
+		return nil, errors.New("max connection age grace requires max connection age")
+	}
+	// clients may ping as often as the server does
+	if p.MinTime == 0 {
+		p.MinTime = p.Time
+	}
+	return p, nil
+}
+
+// parseDuration parses a non-negative duration, an empty string yields zero.
+func parseDuration(name, s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid %s %q", name, s)
+	}
+	return v, nil
+}
+
+// DBCfg tunes the connections to the actions database. Unset values keep the defaults,
+// durations are given like 5s.
+type DBCfg struct {
+	// SQLite journal mode, WAL by default
+	JournalMode string `json:"journalMode"`
+	// SQLite synchronous level, NORMAL by default
+	Synchronous string `json:"synchronous"`
+	// Time SQLite waits for a locked database, 5s by default
+	BusyTimeout string `json:"busyTimeout"`
+	// Connection pool limits, unlimited if 0
+	MaxOpenConns    int    `json:"maxOpenConns"`
+	MaxIdleConns    int    `json:"maxIdleConns"`
+	ConnMaxLifetime string `json:"connMaxLifetime"`
+	ConnMaxIdleTime string `json:"connMaxIdleTime"`
+	// Writes committed in one transaction at most
+	WriteBatchSize int `json:"writeBatchSize"`
+	// Writes waiting for the writer at most, further writers block
+	WriteQueueSize int `json:"writeQueueSize"`
+}
+
+// Durations parses the durations of the settings.
+func (d *DBCfg) Durations() (busyTimeout, maxLifetime, maxIdleTime time.Duration, err error) {
+	if d == nil {
+		return 0, 0, 0, nil
+	}
+	if busyTimeout, err = parseDuration("busy timeout", d.BusyTimeout); err != nil {
+		return
+	}
+	if maxLifetime, err = parseDuration("connection max lifetime", d.ConnMaxLifetime); err != nil {
+		return
+	}
+	maxIdleTime, err = parseDuration("connection max idle time", d.ConnMaxIdleTime)
+	return
+}
+
+// Verify checks the modes, limits and durations of the settings.
+func (d *DBCfg) Verify() error {
+	if d == nil {
+		return nil
+	}
+	switch strings.ToUpper(d.JournalMode) {
+	case "", "DELETE", "TRUNCATE", "PERSIST", "MEMORY", "WAL", "OFF":
+	default:
+		return fmt.Errorf("invalid journal mode %s", d.JournalMode)
+	}
+	switch strings.ToUpper(d.Synchronous) {
+	case "", "OFF", "NORMAL", "FULL", "EXTRA":
+	default:
+		return fmt.Errorf("invalid synchronous level %s", d.Synchronous)
+	}
+	if d.MaxOpenConns < 0 || d.MaxIdleConns < 0 {
+		return errors.New("connection limits must not be negative")
+	}
+	if d.MaxOpenConns > 0 && d.MaxIdleConns > d.MaxOpenConns {
+		return fmt.Errorf("max idle connections %d exceed max open connections %d", d.MaxIdleConns, d.MaxOpenConns)
+	}
+	if d.WriteBatchSize < 0 || d.WriteQueueSize < 0 {
+		return errors.New("write batch and queue size must not be negative")
+	}
+	_, _, _, err := d.Durations()
+	return err
+}
+
+// BackupCfg schedules backups of a SQLite actions database.
+type BackupCfg struct {
+	// Time between backups like 6h, backups are only made on demand if empty
+	Interval string `json:"interval"`
+	// Directory of the backups, backup in the data dir by default
+	Dir string `json:"dir"`
+	// Backups kept, 7 by default
+	Keep int `json:"keep"`
+	// Gzip the backups
+	Compress bool `json:"compress"`
+}
+
+// Durations parses the interval of the backups.
+func (b *BackupCfg) Durations() (interval time.Duration, err error) {
+	if b == nil {
+		return 0, nil
+	}
+	return parseDuration("backup interval", b.Interval)
+}
+
+// Verify checks the interval and the number of backups kept.
+func (b *BackupCfg) Verify() error {
+	if b == nil {
+		return nil
+	}
+	interval, err := b.Durations()
+	if err != nil {
+		return err
+	}
+	if interval > 0 && interval < time.Minute {
+		return fmt.Errorf("backup interval %s is below 1m", b.Interval)
+	}
+	if b.Keep < 0 {
+		return errors.New("backups kept must not be negative")
+	}
+	return nil
+}
+
+// TrackerCfg controls how the transactions of the swaps are followed on the QLC node.
+// Durations are given like 15s.
+type TrackerCfg struct {
+	// Don't track the transactions
+	Disabled bool `json:"disabled"`
+	// Time between the checks of a pending transaction, 15s by default. It doubles after
+	// each check up to maxBackoff, 10m by default.
+	Interval   string `json:"interval"`
+	MaxBackoff string `json:"maxBackoff"`
+	// Transactions still pending after dropAfter are dropped, 1h by default
+	DropAfter string `json:"dropAfter"`
+	// Limit of a lookup on the node, 10s by default
+	Timeout string `json:"timeout"`
+}
+
+// Durations parses the durations of the settings.
+func (t *TrackerCfg) Durations() (interval, maxBackoff, dropAfter, timeout time.Duration, err error) {
+	if t == nil {
+		return 0, 0, 0, 0, nil
+	}
+	if interval, err = parseDuration("tracker interval", t.Interval); err != nil {
+		return
+	}
+	if maxBackoff, err = parseDuration("tracker max backoff", t.MaxBackoff); err != nil {
+		return
+	}
+	if dropAfter, err = parseDuration("tracker drop after", t.DropAfter); err != nil {
+		return
+	}
+	timeout, err = parseDuration("tracker timeout", t.Timeout)
+	return
+}
+
+// Verify checks the durations of the settings.
+func (t *TrackerCfg) Verify() error {
+	interval, maxBackoff, _, _, err := t.Durations()
+	if err != nil {
+		return err
+	}
+	if interval > 0 && interval < time.Second {
+		return fmt.Errorf("tracker interval %s is below 1s", t.Interval)
+	}
+	if interval > 0 && maxBackoff > 0 && maxBackoff < interval {
+		return fmt.Errorf("max backoff %s is below the interval %s", t.MaxBackoff, t.Interval)
+	}
+	return nil
 }
 
 type RPCCfg struct {
@@ -47,26 +384,184 @@ type RPCCfg struct {
 
 	IPCEndpoint string `json:"ipcEndpoint"`
 	IPCEnabled  bool   `json:"ipcEnabled" `
+
+	// Permissions of the HTTP and WebSocket listeners bound to unix:// addresses
+	Socket *SocketCfg `json:"unixSocket"`
+
+	// Additional listeners, served when the transport is enabled
+	HTTPListeners []*ListenerCfg `json:"httpListeners"`
+	WSListeners   []*ListenerCfg `json:"webSocketListeners"`
+	// Listeners serving HTTP and WebSocket clients on the same port. The httpEndpoint and
+	// webSocketEndpoint are served this way too if they are equal.
+	Listeners []*ListenerCfg `json:"listeners"`
+	// Serves HTTP and WebSocket clients on the gRPC gateway under Gateway.Path, the
+	// endpoint, tls and unix socket settings are unused
+	Gateway *ListenerCfg `json:"gateway"`
+}
+
+// ListenerCfg defines an HTTP or WebSocket JSON-RPC listener, e.g. a localhost-only admin
+// port next to a public port with a reduced API set.
+type ListenerCfg struct {
+	// Unique name, used in logs and by the admin api
+	Name     string `json:"name"`
+	Endpoint string `json:"endpoint"`
+	// API modules to expose, only public modules are exposed if empty
+	Modules []string `json:"modules"`
+	// Allowed CORS origins, websocket origins for WebSocket listeners
+	Cors []string `json:"cors"`
+	// Allowed virtual hosts, HTTP only, requests to IP addresses are always allowed
+	VirtualHosts []string `json:"virtualHosts"`
+	// Path prefix, e.g. /rpc, combined listeners only
+	Path   string     `json:"path"`
+	TLS    *TLSCfg    `json:"tls"`
+	Auth   *AuthCfg   `json:"auth"`
+	Socket *SocketCfg `json:"unixSocket"`
+}
+
+// ListenerName returns the name of the i-th listener of a list, unnamed listeners are
+// called <prefix>-<i+1>.
+func ListenerName(prefix string, i int, l *ListenerCfg) string {
+	if l.Name != "" {
+		return l.Name
+	}
+	return fmt.Sprintf("%s-%d", prefix, i+1)
+}
+
+// TLSCfg enables TLS on a listener.
+type TLSCfg struct {
+	CertFile string `json:"certFile"`
+	KeyFile  string `json:"keyFile"`
+	// PEM encoded CAs, clients must present a certificate signed by one of them if set
+	ClientCAFile string `json:"clientCAFile"`
+}
+
+// Config loads the certificates, a nil config disables TLS.
+func (t *TLSCfg) Config() (*tls.Config, error) {
+	if t == nil {
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("tls: %s", err)
+	}
+	cfg := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	if t.ClientCAFile != "" {
+		data, err := ioutil.ReadFile(t.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls: %s", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("tls: no certificates in %s", t.ClientCAFile)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+// AuthCfg requires clients to authenticate with a bearer token or basic auth.
+type AuthCfg struct {
+	Tokens []string          `json:"tokens"`
+	Users  map[string]string `json:"users"`
+}
+
+// redacted is used in place of secrets in logs.
+const redacted = "***"
+
+// redacted returns a copy of the config with the tokens and passwords masked.
+func (a *AuthCfg) redacted() *AuthCfg {
+	if a == nil {
+		return nil
+	}
+	r := &AuthCfg{Users: make(map[string]string, len(a.Users))}
+	for range a.Tokens {
+		r.Tokens = append(r.Tokens, redacted)
+	}
+	for user := range a.Users {
+		r.Users[user] = redacted
+	}
+	return r
+}
+
+func (l *ListenerCfg) redacted() *ListenerCfg {
+	if l == nil || l.Auth == nil {
+		return l
+	}
+	r := *l
+	r.Auth = l.Auth.redacted()
+	return &r
+}
+
+func redactListeners(listeners []*ListenerCfg) []*ListenerCfg {
+	if listeners == nil {
+		return nil
+	}
+	result := make([]*ListenerCfg, 0, len(listeners))
+	for _, l := range listeners {
+		result = append(result, l.redacted())
+	}
+	return result
+}
+
+// SocketCfg sets the file mode and ownership of unix domain sockets, e.g.
+// unix:///run/gbeyond.sock. Sockets in the abstract namespace (unix:@gbeyond) have no
+// file and ignore these settings.
+type SocketCfg struct {
+	// Octal file mode, defaults to 0600
+	Mode string `json:"mode"`
+	// User name or uid owning the socket
+	Owner string `json:"owner"`
+	// Group name or gid owning the socket
+	Group string `json:"group"`
+}
+
+// Options converts the config to listener options, a nil config yields the defaults.
+func (s *SocketCfg) Options() (*util.SocketOptions, error) {
+	if s == nil {
+		return util.DefaultSocketOptions, nil
+	}
+	opts := &util.SocketOptions{Mode: util.DefaultSocketOptions.Mode, Owner: s.Owner, Group: s.Group}
+	if s.Mode != "" {
+		mode, err := strconv.ParseUint(s.Mode, 8, 32)
+		if err != nil || mode > 0777 {
+			return nil, fmt.Errorf("invalid socket mode %q", s.Mode)
+		}
+		opts.Mode = os.FileMode(mode)
+	}
+	return opts, nil
 }
 
 func (c *Config) LogDir() string {
 	return filepath.Join(DefaultDataDir(), "log", time.Now().Format("2006-01-02T15-04"))
 }
 
+// Database returns the DSN of the actions database.
 func (c *Config) Database() string {
+	if c.DatabaseDSN != "" {
+		return c.DatabaseDSN
+	}
 	dir := filepath.Join(DefaultDataDir(), "db")
 	_ = util.CreateDirIfNotExist(dir)
 
 	return filepath.Join(dir, "actions.db")
 }
 
+// BackupDir returns the directory of the database backups.
+func (c *Config) BackupDir() string {
+	if c.Backup != nil && c.Backup.Dir != "" {
+		return c.Backup.Dir
+	}
+	return filepath.Join(DefaultDataDir(), "backup")
+}
+
 func (c *Config) Load() error {
 	f := filepath.Join(DefaultDataDir(), "config.json")
 	if _, err := os.Stat(f); !os.IsNotExist(err) {
 		if data, err := ioutil.ReadFile(f); err == nil {
 			cfg := &Config{}
 			if err := json.Unmarshal(data, cfg); err == nil {
-
+				c.merge(cfg)
 			} else {
 				return err
 			}
@@ -78,6 +573,47 @@ func (c *Config) Load() error {
 	return nil
 }
 
+// merge takes the settings which can't be given on the command line from cfg, command
+// line flags keep precedence over the config file.
+func (c *Config) merge(cfg *Config) {
+	c.Names = cfg.Names
+	if c.DatabaseDSN == "" {
+		c.DatabaseDSN = cfg.DatabaseDSN
+	}
+	c.DB = cfg.DB
+	c.Backup = cfg.Backup
+	c.Tracker = cfg.Tracker
+	if cfg.GRPCCfg != nil && c.GRPCCfg != nil {
+		c.GRPCCfg.GRPCDisabled = cfg.GRPCCfg.GRPCDisabled
+		c.GRPCCfg.GatewayDisabled = cfg.GRPCCfg.GatewayDisabled
+		c.GRPCCfg.Socket = cfg.GRPCCfg.Socket
+		c.GRPCCfg.Deadlines = cfg.GRPCCfg.Deadlines
+		c.GRPCCfg.Server = cfg.GRPCCfg.Server
+	}
+	if cfg.RPCCfg != nil && c.RPCCfg != nil {
+		c.RPCCfg.Socket = cfg.RPCCfg.Socket
+		c.RPCCfg.HTTPListeners = cfg.RPCCfg.HTTPListeners
+		c.RPCCfg.WSListeners = cfg.RPCCfg.WSListeners
+		c.RPCCfg.Listeners = cfg.RPCCfg.Listeners
+		c.RPCCfg.Gateway = cfg.RPCCfg.Gateway
+	}
+}
+
+// Redacted returns a copy of the config which can be logged, the credentials of the
+// listeners are masked.
+func (c *Config) Redacted() *Config {
+	r := *c
+	if c.RPCCfg != nil {
+		rpc := *c.RPCCfg
+		rpc.HTTPListeners = redactListeners(rpc.HTTPListeners)
+		rpc.WSListeners = redactListeners(rpc.WSListeners)
+		rpc.Listeners = redactListeners(rpc.Listeners)
+		rpc.Gateway = rpc.Gateway.redacted()
+		r.RPCCfg = &rpc
+	}
+	return &r
+}
+
 func (c *Config) Save() error {
 	f := filepath.Join(DefaultDataDir(), "config.json")
 	s := util.ToIndentString(c)
@@ -89,6 +625,69 @@ func (c *Config) Verify() error {
 	if err := validator.Validate(c); err != nil {
 		return err
 	}
+	if _, err := c.GRPCCfg.Socket.Options(); err != nil {
+		return err
+	}
+	if _, err := c.RPCCfg.Socket.Options(); err != nil {
+		return err
+	}
+	if _, _, _, err := c.GRPCCfg.Deadlines.Durations(); err != nil {
+		return err
+	}
+	if err := c.GRPCCfg.Server.Verify(); err != nil {
+		return fmt.Errorf("grpc server: %s", err)
+	}
+	if err := c.DB.Verify(); err != nil {
+		return fmt.Errorf("db: %s", err)
+	}
+	if err := c.Backup.Verify(); err != nil {
+		return fmt.Errorf("backup: %s", err)
+	}
+	if err := c.Tracker.Verify(); err != nil {
+		return fmt.Errorf("tracker: %s", err)
+	}
+	// names of the built-in listeners are reserved, unnamed listeners are named by
+	// ListenerName
+	names := map[string]bool{"inproc": true, "ipc": true, "http": true, "ws": true, "gateway": true}
+	for _, list := range []struct {
+		prefix    string
+		listeners []*ListenerCfg
+	}{
+		{"http", c.RPCCfg.HTTPListeners},
+		{"ws", c.RPCCfg.WSListeners},
+		{"rpc", c.RPCCfg.Listeners},
+	} {
+		for i, l := range list.listeners {
+			if l == nil || l.Endpoint == "" {
+				return errors.New("listener without endpoint")
+			}
+			name := ListenerName(list.prefix, i, l)
+			if names[name] {
+				return fmt.Errorf("duplicate listener name %s", name)
+			}
+			names[name] = true
+			if _, err := l.Socket.Options(); err != nil {
+				return err
+			}
+			if l.TLS != nil && (l.TLS.CertFile == "" || l.TLS.KeyFile == "") {
+				return fmt.Errorf("listener %s: tls requires certFile and keyFile", l.Endpoint)
+			}
+			if l.Auth != nil && len(l.Auth.Tokens) == 0 && len(l.Auth.Users) == 0 {
+				return fmt.Errorf("listener %s: auth without tokens or users", l.Endpoint)
+			}
+			if l.Path != "" && !strings.HasPrefix(l.Path, "/") {
+				return fmt.Errorf("listener %s: path must start with /", l.Endpoint)
+			}
+		}
+	}
+	if g := c.RPCCfg.Gateway; g != nil {
+		if !strings.HasPrefix(g.Path, "/") || g.Path == "/" {
+			return errors.New("gateway: path must start with / and not be the root")
+		}
+		if g.Auth != nil && len(g.Auth.Tokens) == 0 && len(g.Auth.Users) == 0 {
+			return errors.New("gateway: auth without tokens or users")
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,133 @@
+package db
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Amount is a token amount in the smallest unit of the token, e.g. 1 QLC is 10^8. It is
+// stored as text, as amounts may exceed 64 bit, and encoded as JSON string.
+type Amount struct {
+	i *big.Int
+}
+
+// NewAmount returns an amount of i units.
+func NewAmount(i *big.Int) Amount {
+	return Amount{i: new(big.Int).Set(i)}
+}
+
+// ParseAmount parses an integer amount of units.
+func ParseAmount(s string) (Amount, error) {
+	i, ok := new(big.Int).SetString(strings.TrimSpace(s), 10)
+	if !ok {
+		return Amount{}, fmt.Errorf("invalid amount %q", s)
+	}
+	return Amount{i: i}, nil
+}
+
+// AmountFromDecimal converts a number of tokens to units, e.g. 1.5 QLC with 8 decimals
+// to 150000000. Fractions of units are rejected rather than rounded.
+func AmountFromDecimal(d Decimal, decimals uint8) (Amount, error) {
+	if d.Scale() > int32(decimals) {
+		return Amount{}, fmt.Errorf("%s has more than %d decimals", d, decimals)
+	}
+	u := d.Mul(NewDecimal(pow10(int32(decimals)), 0))
+	return Amount{i: u.int()}, nil
+}
+
+// Decimal returns the amount in tokens.
+func (a Amount) Decimal(decimals uint8) Decimal {
+	return NewDecimal(a.Int(), int32(decimals))
+}
+
+// Int returns the units as big.Int.
+func (a Amount) Int() *big.Int {
+	if a.i == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(a.i)
+}
+
+func (a Amount) Add(b Amount) Amount {
+	return Amount{i: new(big.Int).Add(a.Int(), b.Int())}
+}
+
+func (a Amount) Sub(b Amount) Amount {
+	return Amount{i: new(big.Int).Sub(a.Int(), b.Int())}
+}
+
+// Cmp compares a and b like big.Int.Cmp.
+func (a Amount) Cmp(b Amount) int {
+	return a.Int().Cmp(b.Int())
+}
+
+func (a Amount) Sign() int {
+	return a.Int().Sign()
+}
+
+func (a Amount) String() string {
+	return a.Int().String()
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON accepts strings and integers.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	v, err := ParseAmount(s)
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
+
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+func (a *Amount) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		*a = Amount{}
+	case string:
+		*a, err = ParseAmount(v)
+	case []byte:
+		*a, err = ParseAmount(string(v))
+	case int64:
+		*a = Amount{i: big.NewInt(v)}
+	default:
+		err = fmt.Errorf("can't scan %T into an amount", src)
+	}
+	return err
+}
+
+func (Amount) GormDataType() string {
+	return "amount"
+}
+
+func (Amount) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	switch db.Dialector.Name() {
+	case "sqlite":
+		return "text"
+	case "mysql":
+		// mysqlDialector rejects values beyond the column
+		return "decimal(65,0)"
+	default:
+		return "numeric(78,0)"
+	}
+}
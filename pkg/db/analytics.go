@@ -0,0 +1,223 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Time buckets of the PnL series.
+const (
+	BucketHour = "hour"
+	BucketDay  = "day"
+	BucketWeek = "week"
+)
+
+// bucketLayout is the format of the bucket keys, which are UTC times.
+const bucketLayout = "2006-01-02 15:04:05"
+
+// averageScale is the number of fractional digits averages are rounded to.
+const averageScale = 18
+
+// TradeStats aggregates the profit of a group of actions. Profits are summed exactly,
+// averages are rounded to averageScale fractional digits.
+type TradeStats struct {
+	Trades        int64   `json:"trades"`
+	TotalProfit   Decimal `json:"totalProfit"`
+	AverageProfit Decimal `json:"averageProfit"`
+	MedianProfit  Decimal `json:"medianProfit"`
+	Gas           int64   `json:"gas"`
+	Wins          int64   `json:"wins"`
+	BestProfit    Decimal `json:"bestProfit"`
+	WorstProfit   Decimal `json:"worstProfit"`
+}
+
+// WinRate is the share of trades with a positive profit.
+func (s *TradeStats) WinRate() float64 {
+	if s.Trades == 0 {
+		return 0
+	}
+	return float64(s.Wins) / float64(s.Trades)
+}
+
+// PairStats are the stats of the actions of a pair.
+type PairStats struct {
+	Pair string `json:"pair"`
+	TradeStats
+}
+
+// PnLPoint are the stats of the actions of a time bucket.
+type PnLPoint struct {
+	// start of the bucket
+	Time time.Time `json:"time"`
+	TradeStats
+	// profit of this and all earlier buckets of the series
+	CumulativeProfit Decimal `json:"cumulativeProfit"`
+}
+
+// group are the stats of the actions with the same key.
+type group struct {
+	key   string
+	stats TradeStats
+}
+
+// aggregate groups the actions matching the filters of q by the SQL expression key, all
+// actions form one group if key is empty. The database counts the actions and sums their
+// profits exactly, see sumDecimals, medians are read with one query per group. Groups
+// are ordered by key.
+func aggregate(db *gorm.DB, q *ActionQuery, key string) ([]*group, error) {
+	expr := key
+	if expr == "" {
+		expr = "''"
+	}
+	profit := numeric(db, "profit")
+	columns := []string{
+		expr + " AS k",
+		"COUNT(*)",
+		sumDecimals(db, "profit"),
+		"MIN(" + profit + ")",
+		"MAX(" + profit + ")",
+		"SUM(CASE WHEN " + profit + " > " + numericParam(db) + " THEN 1 ELSE 0 END)",
+		"SUM(gas)",
+	}
+	// Rows doesn't add the soft delete condition
+	rows, err := q.Filter(db.Model(&InchAction{})).Where("deleted_at IS NULL").
+		Select(strings.Join(columns, ", "), "0").Group("k").Order("k").Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []*group
+	for rows.Next() {
+		g := &group{}
+		s := &g.stats
+		if err := rows.Scan(&g.key, &s.Trades, &s.TotalProfit, &s.WorstProfit, &s.BestProfit, &s.Wins, &s.Gas); err != nil {
+			return nil, err
+		}
+		s.AverageProfit = s.TotalProfit.Quo(s.Trades, averageScale)
+		result = append(result, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for _, g := range result {
+		tx := q.Filter(db.Model(&InchAction{}))
+		if key != "" {
+			tx = tx.Where(key+" = ?", g.key)
+		}
+		if g.stats.MedianProfit, err = median(db, tx, g.stats.Trades); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// median returns the median profit of the n actions of tx, the mean of the middle
+// profits if n is even.
+func median(db, tx *gorm.DB, n int64) (Decimal, error) {
+	var profits []Decimal
+	err := tx.Order(numeric(db, "profit")).Offset(int((n-1)/2)).Limit(int(2-n%2)).
+		Pluck("profit", &profits).Error
+	if err != nil {
+		return Decimal{}, err
+	}
+	switch len(profits) {
+	case 0:
+		// the actions were deleted in the meantime
+		return Decimal{}, nil
+	case 1:
+		return profits[0], nil
+	default:
+		// the mean of the middle profits is exact with one more digit
+		sum := profits[0].Add(profits[1])
+		return sum.Quo(2, sum.Scale()+1), nil
+	}
+}
+
+// ActionStats returns the stats of all actions matching the filters of q.
+func ActionStats(db *gorm.DB, q *ActionQuery) (*TradeStats, error) {
+	groups, err := aggregate(db, q, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return &TradeStats{}, nil
+	}
+	return &groups[0].stats, nil
+}
+
+// ActionStatsByPair returns the stats of the actions matching the filters of q by pair.
+func ActionStatsByPair(db *gorm.DB, q *ActionQuery) ([]*PairStats, error) {
+	groups, err := aggregate(db, q, "pair")
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*PairStats, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, &PairStats{Pair: g.key, TradeStats: g.stats})
+	}
+	return result, nil
+}
+
+// PnLSeries returns the stats of the actions matching the filters of q by time bucket,
+// buckets without actions are omitted.
+func PnLSeries(db *gorm.DB, q *ActionQuery, bucket string) ([]*PnLPoint, error) {
+	key, err := bucketExpr(db, bucket)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := aggregate(db, q, key)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*PnLPoint, 0, len(groups))
+	var cumulative Decimal
+	for _, g := range groups {
+		t, err := time.Parse(bucketLayout, g.key)
+		if err != nil {
+			return nil, fmt.Errorf("bucket %s: %s", g.key, err)
+		}
+		stats := g.stats
+		cumulative = cumulative.Add(stats.TotalProfit)
+		result = append(result, &PnLPoint{Time: t, TradeStats: stats, CumulativeProfit: cumulative})
+	}
+	return result, nil
+}
+
+// bucketExpr returns the SQL expression of the start of the bucket of created_at in UTC,
+// formatted like bucketLayout. Weeks start on monday.
+func bucketExpr(db *gorm.DB, bucket string) (string, error) {
+	if bucket != BucketHour && bucket != BucketDay && bucket != BucketWeek {
+		return "", fmt.Errorf("unknown time bucket %s", bucket)
+	}
+	switch name := db.Dialector.Name(); name {
+	case DriverSQLite:
+		switch bucket {
+		case BucketHour:
+			return "strftime('%Y-%m-%d %H:00:00', created_at)", nil
+		case BucketDay:
+			return "strftime('%Y-%m-%d 00:00:00', created_at)", nil
+		default:
+			// the monday on or before the day
+			return "strftime('%Y-%m-%d 00:00:00', created_at, '-6 days', 'weekday 1')", nil
+		}
+	case DriverPostgres:
+		return fmt.Sprintf("to_char(date_trunc('%s', created_at AT TIME ZONE 'UTC'), 'YYYY-MM-DD HH24:MI:SS')", bucket), nil
+	case DriverMySQL:
+		// DATETIME columns hold the UTC time of the default connection location
+		switch bucket {
+		case BucketHour:
+			return "DATE_FORMAT(created_at, '%Y-%m-%d %H:00:00')", nil
+		case BucketDay:
+			return "DATE_FORMAT(created_at, '%Y-%m-%d 00:00:00')", nil
+		default:
+			return "DATE_FORMAT(created_at - INTERVAL WEEKDAY(created_at) DAY, '%Y-%m-%d 00:00:00')", nil
+		}
+	default:
+		return "", fmt.Errorf("time buckets are not supported on %s", name)
+	}
+}
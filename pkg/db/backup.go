@@ -0,0 +1,419 @@
+package db
+
+import (
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/drip/beyond/pkg/log"
+	"github.com/mattn/go-sqlite3"
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// DefaultBackupKeep is the number of backups kept by default.
+const DefaultBackupKeep = 7
+
+// backupTimeFormat names the backups by their UTC time, names sort by time.
+const backupTimeFormat = "20060102T150405.000Z"
+
+// RestoreSuffix names the database replaced by a restore.
+const RestoreSuffix = ".pre-restore"
+
+// BackupOptions select where backups are written and how many are kept.
+type BackupOptions struct {
+	Dir string
+	// backups kept, older ones are removed, DefaultBackupKeep if 0
+	Keep int
+	// gzip the backups
+	Compress bool
+}
+
+// BackupInfo describes a backup file.
+type BackupInfo struct {
+	Path string    `json:"path"`
+	Size int64     `json:"size"`
+	Time time.Time `json:"time"`
+}
+
+// Backup writes a consistent copy of a running SQLite database to opts.Dir with SQLite's
+// online backup API, checks its integrity and removes the backups exceeding opts.Keep.
+// Backups are named after the database and the time, e.g. actions-20201019T152300.000Z.db.gz.
+func Backup(ctx context.Context, database *gorm.DB, opts *BackupOptions) (*BackupInfo, error) {
+	file, err := databaseFile(database.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(opts.Dir, 0700); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	prefix := backupPrefix(file)
+	path := filepath.Join(opts.Dir, prefix+now.Format(backupTimeFormat)+".db")
+	tmp := path + ".tmp"
+	defer removeDatabase(tmp)
+	if err := snapshot(ctx, database, tmp); err != nil {
+		return nil, fmt.Errorf("backup: %s", err)
+	}
+	if err := checkIntegrity(tmp); err != nil {
+		return nil, fmt.Errorf("backup: %s", err)
+	}
+	if opts.Compress {
+		path += ".gz"
+		if err := gzipFile(tmp, path+".tmp"); err != nil {
+			os.Remove(path + ".tmp")
+			return nil, fmt.Errorf("backup: %s", err)
+		}
+		tmp = path + ".tmp"
+		defer os.Remove(tmp)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return nil, err
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := opts.Keep
+	if keep <= 0 {
+		keep = DefaultBackupKeep
+	}
+	backups, err := ListBackups(opts.Dir, file)
+	if err != nil {
+		return nil, err
+	}
+	for i := keep; i < len(backups); i++ {
+		if err := os.Remove(backups[i].Path); err != nil {
+			return nil, err
+		}
+	}
+	return &BackupInfo{Path: path, Size: fi.Size(), Time: now}, nil
+}
+
+// databaseFile returns the path of a SQLite database.
+func databaseFile(database *gorm.DB) (string, error) {
+	if name := database.Dialector.Name(); name != DriverSQLite {
+		return "", fmt.Errorf("backups of %s databases aren't supported, use the tools of the server", name)
+	}
+	var file string
+	if err := database.Raw("SELECT file FROM pragma_database_list WHERE name = 'main'").Scan(&file).Error; err != nil {
+		return "", err
+	}
+	if file == "" {
+		return "", errors.New("in-memory databases can't be backed up")
+	}
+	return file, nil
+}
+
+// ListBackups returns the backups of the database file in dir, the latest first.
+func ListBackups(dir, file string) ([]*BackupInfo, error) {
+	prefix := backupPrefix(file)
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var backups []*BackupInfo
+	for _, fi := range infos {
+		name := fi.Name()
+		if fi.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		ts := strings.TrimSuffix(strings.TrimSuffix(name, ".gz"), ".db")
+		t, err := time.Parse(backupTimeFormat, strings.TrimPrefix(ts, prefix))
+		if err != nil || ts == name {
+			continue
+		}
+		backups = append(backups, &BackupInfo{Path: filepath.Join(dir, name), Size: fi.Size(), Time: t})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Time.After(backups[j].Time)
+	})
+	return backups, nil
+}
+
+// Restore replaces the SQLite database file with a backup after checking the integrity
+// and the schema version of the backup. The replaced database is kept next to it with the
+// suffix .pre-restore. The database must not be in use.
+func Restore(backup, file string) error {
+	tmp := file + ".restore"
+	defer removeDatabase(tmp)
+	if strings.HasSuffix(backup, ".gz") {
+		if err := gunzipFile(backup, tmp); err != nil {
+			return err
+		}
+	} else if err := copyFile(backup, tmp); err != nil {
+		return err
+	}
+	if err := checkIntegrity(tmp); err != nil {
+		return fmt.Errorf("%s: %s", backup, err)
+	}
+	version, err := fileSchemaVersion(tmp)
+	if err != nil {
+		return fmt.Errorf("%s: %s", backup, err)
+	}
+	if version > LatestVersion() {
+		return fmt.Errorf("%s: schema version %d is newer than the latest %d", backup, version, LatestVersion())
+	}
+
+	// the journal files belong to the replaced database, SQLite would apply a stale WAL
+	// to the restored one
+	for _, suffix := range journalSuffixes {
+		if err := os.Rename(file+suffix, file+RestoreSuffix+suffix); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(tmp, file)
+}
+
+// journalSuffixes are the suffixes of a SQLite database file and its journal files.
+var journalSuffixes = []string{"", "-wal", "-shm", "-journal"}
+
+// removeDatabase removes a SQLite database file and its journal files.
+func removeDatabase(path string) {
+	for _, suffix := range journalSuffixes {
+		os.Remove(path + suffix)
+	}
+}
+
+// snapshot copies the main database to path on a connection of database. The copy runs
+// in a single step, i.e. one read transaction, so it is consistent and in WAL mode
+// doesn't block writers. The copy is a single file, it doesn't use WAL.
+func snapshot(ctx context.Context, database *gorm.DB, path string) error {
+	sqlDB, err := database.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return conn.Raw(func(driverConn interface{}) error {
+		src, ok := driverConn.(*sqlite3.SQLiteConn)
+		if !ok {
+			return fmt.Errorf("unexpected SQLite connection %T", driverConn)
+		}
+		removeDatabase(path)
+		c, err := (&sqlite3.SQLiteDriver{}).Open(path)
+		if err != nil {
+			return err
+		}
+		dst := c.(*sqlite3.SQLiteConn)
+		defer dst.Close()
+		b, err := dst.Backup("main", src, "main")
+		if err != nil {
+			return err
+		}
+		if _, err := b.Step(-1); err != nil {
+			b.Close()
+			return err
+		}
+		if err := b.Finish(); err != nil {
+			return err
+		}
+		_, err = dst.Exec("PRAGMA journal_mode = DELETE", nil)
+		return err
+	})
+}
+
+// checkIntegrity runs SQLite's integrity check on the database file.
+func checkIntegrity(path string) error {
+	sqlDB, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+	rows, err := sqlDB.Query("PRAGMA integrity_check")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	var problems []string
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			return err
+		}
+		if s != "ok" {
+			problems = append(problems, s)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("integrity check failed: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func fileSchemaVersion(path string) (int, error) {
+	database, err := gorm.Open(sqlite.Open("file:"+path+"?mode=ro"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		return 0, err
+	}
+	if sqlDB, err := database.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+	return SchemaVersion(database)
+}
+
+// backupPrefix returns the prefix of the backup names of a database file, e.g. actions-.
+func backupPrefix(file string) string {
+	base := filepath.Base(file)
+	return strings.TrimSuffix(base, filepath.Ext(base)) + "-"
+}
+
+func gzipFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	zw := gzip.NewWriter(out)
+	if _, err := io.Copy(zw, in); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return out.Sync()
+}
+
+func gunzipFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	zr, err := gzip.NewReader(in)
+	if err != nil {
+		return fmt.Errorf("%s: %s", src, err)
+	}
+	return writeFile(dst, zr)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	return writeFile(dst, in)
+}
+
+func writeFile(path string, r io.Reader) error {
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// BackupScheduler backs a database up periodically and on demand, one backup at a time.
+type BackupScheduler struct {
+	db       *gorm.DB
+	opts     *BackupOptions
+	interval time.Duration
+	logger   *zap.SugaredLogger
+
+	mu   sync.Mutex
+	quit chan struct{}
+	done chan struct{}
+}
+
+// NewBackupScheduler creates a scheduler backing database up every interval, backups are
+// only made on demand if interval is 0.
+func NewBackupScheduler(database *gorm.DB, opts *BackupOptions, interval time.Duration) *BackupScheduler {
+	return &BackupScheduler{
+		db:       database,
+		opts:     opts,
+		interval: interval,
+		logger:   log.NewLogger("db/backup"),
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start runs the scheduled backups.
+func (s *BackupScheduler) Start() {
+	if s.interval <= 0 {
+		close(s.done)
+		return
+	}
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := s.Backup(context.Background()); err != nil {
+					s.logger.Error(err)
+				}
+			case <-s.quit:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the scheduled backups and waits for a running one.
+func (s *BackupScheduler) Stop() {
+	close(s.quit)
+	<-s.done
+	s.mu.Lock()
+	s.mu.Unlock()
+}
+
+// Backup backs the database up now, it waits for a running backup first.
+func (s *BackupScheduler) Backup(ctx context.Context) (*BackupInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	start := time.Now()
+	info, err := Backup(ctx, s.db, s.opts)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Infof("backed up to %s (%d bytes) in %s", info.Path, info.Size, time.Since(start))
+	return info, nil
+}
+
+// Backups lists the backups of the database, the latest first.
+func (s *BackupScheduler) Backups() ([]*BackupInfo, error) {
+	file, err := databaseFile(s.db)
+	if err != nil {
+		return nil, err
+	}
+	return ListBackups(s.opts.Dir, file)
+}
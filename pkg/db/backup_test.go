@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBackup(t *testing.T) {
+	database, cleanup := newTestDB(t)
+	defer cleanup()
+	if database.Dialector.Name() != DriverSQLite {
+		t.Skip("backups are SQLite only")
+	}
+	dir, err := ioutil.TempDir("", "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	opts := &BackupOptions{Dir: filepath.Join(dir, "backup"), Keep: 2}
+	var last *BackupInfo
+	for i, compress := range []bool{false, true, true} {
+		if err := InsertSwapResult(database, &InchAction{Pair: "QLC/QGAS", TxHash: string(rune('a' + i))}); err != nil {
+			t.Fatal(err)
+		}
+		opts.Compress = compress
+		if last, err = Backup(context.Background(), database, opts); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+	if filepath.Ext(last.Path) != ".gz" || last.Size == 0 {
+		t.Fatalf("unexpected backup %+v", last)
+	}
+	backups, err := ListBackups(opts.Dir, "actions.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 2 || backups[0].Path != last.Path {
+		t.Fatalf("expected the 2 latest backups, got %d", len(backups))
+	}
+	files, _ := ioutil.ReadDir(opts.Dir)
+	if len(files) != 2 {
+		t.Fatalf("expected no temporary files, got %d files", len(files))
+	}
+
+	// restore over an existing database
+	file := filepath.Join(dir, "restored.db")
+	if err := ioutil.WriteFile(file, []byte("previous"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := Restore(last.Path, file); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := ioutil.ReadFile(file + RestoreSuffix); err != nil || string(data) != "previous" {
+		t.Fatalf("replaced database not kept: %v", err)
+	}
+	restored, err := Open(file, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if sqlDB, err := restored.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}()
+	if n, err := CountInchAction(restored); err != nil || n != 3 {
+		t.Fatalf("expected 3 restored actions, got %d: %v", n, err)
+	}
+	if v, err := SchemaVersion(restored); err != nil || v != LatestVersion() {
+		t.Fatalf("unexpected schema version %d: %v", v, err)
+	}
+
+	// a damaged backup is rejected and the database kept
+	damaged := filepath.Join(dir, "damaged.db")
+	if err := ioutil.WriteFile(damaged, []byte("not a database"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := Restore(damaged, file); err == nil {
+		t.Fatal("expected the restore of a damaged backup to fail")
+	}
+	if n, err := CountInchAction(restored); err != nil || n != 3 {
+		t.Fatalf("expected the database to be kept, got %d actions: %v", n, err)
+	}
+}
@@ -0,0 +1,243 @@
+package db
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Decimal is an exact decimal number, e.g. a price or the profit of a swap. It is stored
+// as text, the canonical form without trailing zeros, and encoded as JSON string.
+type Decimal struct {
+	// value is unscaled / 10^scale
+	unscaled *big.Int
+	scale    int32
+}
+
+// NewDecimal returns unscaled * 10^-scale.
+func NewDecimal(unscaled *big.Int, scale int32) Decimal {
+	return Decimal{unscaled: new(big.Int).Set(unscaled), scale: scale}.normalize()
+}
+
+// ParseDecimal parses decimals like 12, -0.5 or 1.25e-3.
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	exp := int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+		exp, s = e, s[:i]
+	}
+	digits := s
+	scale := int64(0)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		digits = s[:i] + s[i+1:]
+		scale = int64(len(s) - i - 1)
+	}
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+	if !ok || strings.ContainsAny(digits, "_xXoObB") {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	scale -= exp
+	if scale > 1<<20 || scale < -(1<<20) {
+		return Decimal{}, fmt.Errorf("decimal %q out of range", s)
+	}
+	return Decimal{unscaled: unscaled, scale: int32(scale)}.normalize(), nil
+}
+
+// DecimalFromFloat returns the shortest decimal which converts back to f.
+func DecimalFromFloat(f float64) (Decimal, error) {
+	return ParseDecimal(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// normalize removes trailing zeros, so equal values have equal representations.
+func (d Decimal) normalize() Decimal {
+	u := new(big.Int).Set(d.int())
+	scale := d.scale
+	if u.Sign() == 0 {
+		return Decimal{unscaled: u}
+	}
+	ten := big.NewInt(10)
+	q, r := new(big.Int), new(big.Int)
+	for {
+		q.QuoRem(u, ten, r)
+		if r.Sign() != 0 {
+			break
+		}
+		u.Set(q)
+		scale--
+	}
+	// integers are kept unscaled
+	if scale < 0 {
+		u.Mul(u, new(big.Int).Exp(ten, big.NewInt(int64(-scale)), nil))
+		scale = 0
+	}
+	return Decimal{unscaled: u, scale: scale}
+}
+
+// rescale returns the unscaled values of d and e at their common scale.
+func (d Decimal) rescale(e Decimal) (*big.Int, *big.Int, int32) {
+	a, b := new(big.Int).Set(d.int()), new(big.Int).Set(e.int())
+	scale := d.scale
+	switch {
+	case d.scale < e.scale:
+		a.Mul(a, pow10(e.scale-d.scale))
+		scale = e.scale
+	case d.scale > e.scale:
+		b.Mul(b, pow10(d.scale-e.scale))
+	}
+	return a, b, scale
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) Add(e Decimal) Decimal {
+	a, b, scale := d.rescale(e)
+	return Decimal{unscaled: a.Add(a, b), scale: scale}.normalize()
+}
+
+func (d Decimal) Sub(e Decimal) Decimal {
+	a, b, scale := d.rescale(e)
+	return Decimal{unscaled: a.Sub(a, b), scale: scale}.normalize()
+}
+
+func (d Decimal) Mul(e Decimal) Decimal {
+	u := new(big.Int).Mul(d.int(), e.int())
+	return Decimal{unscaled: u, scale: d.scale + e.scale}.normalize()
+}
+
+// Quo returns d / n rounded half away from zero to scale fractional digits.
+func (d Decimal) Quo(n int64, scale int32) Decimal {
+	r := new(big.Rat).SetFrac(d.int(), pow10(d.scale))
+	r.Quo(r, new(big.Rat).SetInt64(n))
+	num := new(big.Int).Mul(r.Num(), pow10(scale))
+	q, m := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	if m.Abs(m).Lsh(m, 1).Cmp(r.Denom()) >= 0 {
+		q.Add(q, big.NewInt(int64(num.Sign())))
+	}
+	return Decimal{unscaled: q, scale: scale}.normalize()
+}
+
+// Cmp compares d and e like big.Int.Cmp.
+func (d Decimal) Cmp(e Decimal) int {
+	a, b, _ := d.rescale(e)
+	return a.Cmp(b)
+}
+
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// Scale returns the number of fractional digits.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// fits reports whether a decimal(precision, scale) SQL column stores d exactly.
+func (d Decimal) fits(precision, scale int32) bool {
+	if d.scale > scale {
+		return false
+	}
+	limit := pow10(precision - scale + d.scale)
+	return new(big.Int).Abs(d.int()).Cmp(limit) < 0
+}
+
+// Float64 returns the nearest float, for statistics where exactness doesn't matter.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+func (d Decimal) String() string {
+	s := d.int().String()
+	if d.scale <= 0 {
+		return s
+	}
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	if pad := int(d.scale) + 1 - len(s); pad > 0 {
+		s = strings.Repeat("0", pad) + s
+	}
+	s = s[:len(s)-int(d.scale)] + "." + s[len(s)-int(d.scale):]
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON accepts strings and numbers.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// Value stores the decimal as text, numeric SQL types would round it.
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+func (d *Decimal) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		*d = Decimal{}
+	case string:
+		*d, err = ParseDecimal(v)
+	case []byte:
+		*d, err = ParseDecimal(string(v))
+	case int64:
+		*d = NewDecimal(big.NewInt(v), 0)
+	case float64:
+		*d, err = DecimalFromFloat(v)
+	default:
+		err = fmt.Errorf("can't scan %T into a decimal", src)
+	}
+	return err
+}
+
+func (Decimal) GormDataType() string {
+	return "decimal"
+}
+
+func (Decimal) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	switch db.Dialector.Name() {
+	case "sqlite":
+		return "text"
+	case "mysql":
+		// mysqlDialector rejects values beyond the column
+		return "decimal(65,30)"
+	default:
+		return "numeric"
+	}
+}
@@ -1,17 +1,27 @@
 package db
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
+// InchAction is a swap of Pair, e.g. QLC/QGAS swaps InPut units of QLC for OutPut units
+// of QGAS. Price and Profit are exact decimals, Profit is given in the output token.
+// Status follows the transaction TxHash on the chain, it is empty without a transaction.
 type InchAction struct {
 	gorm.Model
 	Pair        string  `json:"pair"`
-	InPut       string  `json:"input"`
-	OutPut      string  `json:"output"`
+	InPut       Amount  `json:"input"`
+	OutPut      Amount  `json:"output"`
 	Gas         int64   `json:"gas"`
-	Price       string  `json:price`
-	Profit      float64 `json:"profit"`
+	Price       Decimal `json:"price"`
+	Profit      Decimal `json:"profit"`
 	TxHash      string  `json:"tx_hash"`
 	IncludeSake bool    `json:"includeSake"`
+	// one of the Tx statuses
+	Status      string     `json:"status" gorm:"index"`
+	ConfirmedAt *time.Time `json:"confirmed_at"`
+	// gas of the confirmed transaction, Gas is the estimate of the swap
+	GasUsed int64 `json:"gas_used"`
 }
@@ -0,0 +1,435 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Migration changes the schema from Version-1 to Version, Down reverts it. Migrations
+// use their own copies of the models, so they keep working when the models change.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+func (m *Migration) String() string {
+	return fmt.Sprintf("%d %s", m.Version, m.Name)
+}
+
+// migrations are applied in order, the version of a migration is its position.
+var migrations = []*Migration{
+	{Name: "create actions", Up: createActions, Down: dropActions},
+	{Name: "create tokens", Up: createTokens, Down: dropTokens},
+	{Name: "exact action amounts", Up: exactActionTypes, Down: floatActionTypes},
+	{Name: "transaction status", Up: addTxStatus, Down: dropTxStatus},
+}
+
+func init() {
+	for i, m := range migrations {
+		m.Version = i + 1
+	}
+}
+
+// LatestVersion is the schema version after all migrations.
+func LatestVersion() int {
+	return len(migrations)
+}
+
+// Migrations returns all migrations in order.
+func Migrations() []*Migration {
+	return migrations
+}
+
+// schemaVersion records an applied migration.
+type schemaVersion struct {
+	Version   int `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+func (schemaVersion) TableName() string {
+	return "schema_version"
+}
+
+// MigrationPlan are the migrations from one version to another, applied in order. The
+// Down migrations run if To is below From.
+type MigrationPlan struct {
+	From  int
+	To    int
+	Steps []*Migration
+}
+
+// Down reports whether the plan reverts migrations.
+func (p *MigrationPlan) Down() bool {
+	return p.To < p.From
+}
+
+// SchemaVersion returns the version of the schema. Databases created before versions
+// were recorded are detected by their tables.
+func SchemaVersion(db *gorm.DB) (int, error) {
+	if !db.Migrator().HasTable(&schemaVersion{}) {
+		return baselineVersion(db)
+	}
+	var version int
+	if err := db.Model(&schemaVersion{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error; err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// baselineVersion is the version of a schema without a schema_version table.
+func baselineVersion(db *gorm.DB) (int, error) {
+	if !db.Migrator().HasTable(&actionV1{}) {
+		return 0, nil
+	}
+	columns, err := db.Migrator().ColumnTypes(&actionV1{})
+	if err != nil {
+		return 0, err
+	}
+	for _, c := range columns {
+		if c.Name() != "profit" {
+			continue
+		}
+		switch strings.ToLower(c.DatabaseTypeName()) {
+		case "real", "float8", "double", "double precision":
+			return 1, nil
+		}
+	}
+	return 3, nil
+}
+
+// Migrate migrates the schema to version target, the latest version if target is
+// negative. Each migration runs in a transaction together with its version record, a
+// failed migration leaves the schema at the version before it. With dryRun only the plan
+// is returned.
+func Migrate(db *gorm.DB, target int, dryRun bool) (*MigrationPlan, error) {
+	if target < 0 {
+		target = LatestVersion()
+	}
+	if target > LatestVersion() {
+		return nil, fmt.Errorf("unknown schema version %d, latest is %d", target, LatestVersion())
+	}
+	from, err := SchemaVersion(db)
+	if err != nil {
+		return nil, fmt.Errorf("schema version: %s", err)
+	}
+	if from > LatestVersion() {
+		return nil, fmt.Errorf("schema version %d is newer than the latest known version %d", from, LatestVersion())
+	}
+
+	plan := &MigrationPlan{From: from, To: target}
+	if target >= from {
+		plan.Steps = migrations[from:target]
+	} else {
+		for v := from; v > target; v-- {
+			plan.Steps = append(plan.Steps, migrations[v-1])
+		}
+	}
+	if dryRun {
+		return plan, nil
+	}
+
+	if err := recordBaseline(db, from); err != nil {
+		return nil, fmt.Errorf("schema version: %s", err)
+	}
+	for _, m := range plan.Steps {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if plan.Down() {
+				if m.Down == nil {
+					return errors.New("irreversible")
+				}
+				if err := m.Down(tx); err != nil {
+					return err
+				}
+				return tx.Delete(&schemaVersion{}, m.Version).Error
+			}
+			if err := m.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaVersion{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %s", m, err)
+		}
+	}
+	return plan, nil
+}
+
+// recordBaseline creates the schema_version table, the migrations up to a detected
+// version are recorded as applied.
+func recordBaseline(db *gorm.DB, version int) error {
+	if db.Migrator().HasTable(&schemaVersion{}) {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.AutoMigrate(&schemaVersion{}); err != nil {
+			return err
+		}
+		for _, m := range migrations[:version] {
+			if err := tx.Create(&schemaVersion{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+const actionTable = "inch_actions"
+
+// actionV1 are the actions with free-form amounts and a float profit.
+type actionV1 struct {
+	gorm.Model
+	Pair        string
+	InPut       string
+	OutPut      string
+	Gas         int64
+	Price       string
+	Profit      float64
+	TxHash      string
+	IncludeSake bool
+}
+
+func (actionV1) TableName() string {
+	return actionTable
+}
+
+// actionV3 are the actions with amounts in units and exact decimals.
+type actionV3 struct {
+	gorm.Model
+	Pair        string
+	InPut       Amount
+	OutPut      Amount
+	Gas         int64
+	Price       Decimal
+	Profit      Decimal
+	TxHash      string
+	IncludeSake bool
+}
+
+func (actionV3) TableName() string {
+	return actionTable
+}
+
+// actionV4 are the actions with the status of their transaction.
+type actionV4 struct {
+	gorm.Model
+	Pair        string
+	InPut       Amount
+	OutPut      Amount
+	Gas         int64
+	Price       Decimal
+	Profit      Decimal
+	TxHash      string
+	IncludeSake bool
+	Status      string `gorm:"index"`
+	ConfirmedAt *time.Time
+	GasUsed     int64
+}
+
+func (actionV4) TableName() string {
+	return actionTable
+}
+
+type tokenV2 struct {
+	Symbol   string `gorm:"primaryKey"`
+	Decimals uint8
+}
+
+func (tokenV2) TableName() string {
+	return "tokens"
+}
+
+func createActions(tx *gorm.DB) error {
+	return tx.AutoMigrate(&actionV1{})
+}
+
+func dropActions(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(&actionV1{})
+}
+
+// createTokens creates the token metadata with the default tokens, existing entries are
+// kept.
+func createTokens(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&tokenV2{}); err != nil {
+		return err
+	}
+	tokens := make([]*tokenV2, 0, len(defaultTokens))
+	for _, t := range defaultTokens {
+		tokens = append(tokens, &tokenV2{Symbol: t.Symbol, Decimals: t.Decimals})
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tokens).Error
+}
+
+func dropTokens(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(&tokenV2{})
+}
+
+// rebuildActions creates the actions table of model, rows are copied from the previous
+// table by copyRows.
+func rebuildActions(tx *gorm.DB, model interface{}, copyRows func(from *gorm.DB) error) error {
+	const previous = actionTable + "_previous"
+	// the index name moves with the table, it is created again for the new one
+	const index = "idx_inch_actions_deleted_at"
+	if tx.Migrator().HasIndex(model, index) {
+		if err := tx.Migrator().DropIndex(model, index); err != nil {
+			return err
+		}
+	}
+	if err := tx.Migrator().RenameTable(actionTable, previous); err != nil {
+		return err
+	}
+	if err := tx.AutoMigrate(model); err != nil {
+		return err
+	}
+	if err := copyRows(tx.Table(previous).Unscoped()); err != nil {
+		return err
+	}
+	if err := resetSequence(tx, actionTable); err != nil {
+		return err
+	}
+	return tx.Migrator().DropTable(previous)
+}
+
+// exactActionTypes converts actions stored with a float profit and free-form amounts.
+// Amounts are converted to units with the token decimals, see legacyAmount, profits are
+// converted to the shortest decimal of the float. Values which can't be converted
+// exactly abort the migration.
+func exactActionTypes(tx *gorm.DB) error {
+	return rebuildActions(tx, &actionV3{}, func(from *gorm.DB) error {
+		var rows []*actionV1
+		return from.FindInBatches(&rows, 500, func(_ *gorm.DB, _ int) error {
+			actions := make([]*actionV3, 0, len(rows))
+			for _, row := range rows {
+				action, err := convertLegacyAction(tx, row)
+				if err != nil {
+					return fmt.Errorf("action %d: %s", row.ID, err)
+				}
+				actions = append(actions, action)
+			}
+			return tx.Create(&actions).Error
+		}).Error
+	})
+}
+
+// floatActionTypes converts the actions back to amounts in tokens and a float profit.
+func floatActionTypes(tx *gorm.DB) error {
+	return rebuildActions(tx, &actionV1{}, func(from *gorm.DB) error {
+		var rows []*actionV3
+		return from.FindInBatches(&rows, 500, func(_ *gorm.DB, _ int) error {
+			actions := make([]*actionV1, 0, len(rows))
+			for _, row := range rows {
+				inDecimals, outDecimals, err := PairDecimals(tx, row.Pair)
+				if err != nil {
+					return fmt.Errorf("action %d: %s", row.ID, err)
+				}
+				action := &actionV1{
+					Model:       row.Model,
+					Pair:        row.Pair,
+					InPut:       row.InPut.Decimal(inDecimals).String(),
+					OutPut:      row.OutPut.Decimal(outDecimals).String(),
+					Gas:         row.Gas,
+					Profit:      row.Profit.Float64(),
+					TxHash:      row.TxHash,
+					IncludeSake: row.IncludeSake,
+				}
+				if row.Price.Sign() != 0 {
+					action.Price = row.Price.String()
+				}
+				actions = append(actions, action)
+			}
+			return tx.Create(&actions).Error
+		}).Error
+	})
+}
+
+func convertLegacyAction(db *gorm.DB, row *actionV1) (*actionV3, error) {
+	inDecimals, outDecimals, err := PairDecimals(db, row.Pair)
+	if err != nil {
+		return nil, err
+	}
+	action := &actionV3{
+		Model:       row.Model,
+		Pair:        row.Pair,
+		Gas:         row.Gas,
+		TxHash:      row.TxHash,
+		IncludeSake: row.IncludeSake,
+	}
+	if action.InPut, err = legacyAmount(row.InPut, inDecimals); err != nil {
+		return nil, fmt.Errorf("input: %s", err)
+	}
+	if action.OutPut, err = legacyAmount(row.OutPut, outDecimals); err != nil {
+		return nil, fmt.Errorf("output: %s", err)
+	}
+	if row.Price != "" {
+		if action.Price, err = ParseDecimal(row.Price); err != nil {
+			return nil, fmt.Errorf("price: %s", err)
+		}
+	}
+	if action.Profit, err = DecimalFromFloat(row.Profit); err != nil {
+		return nil, fmt.Errorf("profit: %s", err)
+	}
+	return action, nil
+}
+
+// legacyAmount converts a legacy amount to units. Amounts were written in tokens, but
+// writers which stored units can't be told apart by the column, so the format of each
+// amount is detected: amounts with a fractional part are tokens, integers of at least
+// one token in units, 10^decimals, are units, as no swap moves that many tokens, smaller
+// integers are tokens.
+func legacyAmount(s string, decimals uint8) (Amount, error) {
+	if s == "" {
+		return Amount{}, nil
+	}
+	d, err := ParseDecimal(s)
+	if err != nil {
+		return Amount{}, err
+	}
+	if d.Scale() == 0 && d.Cmp(NewDecimal(pow10(int32(decimals)), 0)) >= 0 {
+		return NewAmount(d.int()), nil
+	}
+	a, err := AmountFromDecimal(d, decimals)
+	if err != nil {
+		return Amount{}, fmt.Errorf("amount %s in tokens: %s", s, err)
+	}
+	return a, nil
+}
+
+// addTxStatus adds the transaction status. The transactions of existing actions are
+// unknown rather than pending, they may be long final and would be dropped otherwise.
+func addTxStatus(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&actionV4{}); err != nil {
+		return err
+	}
+	return tx.Model(&actionV4{}).Unscoped().Where("tx_hash IS NOT NULL AND tx_hash <> ''").
+		Update("status", TxUnknown).Error
+}
+
+func dropTxStatus(tx *gorm.DB) error {
+	return rebuildActions(tx, &actionV3{}, func(from *gorm.DB) error {
+		var rows []*actionV4
+		return from.FindInBatches(&rows, 500, func(_ *gorm.DB, _ int) error {
+			actions := make([]*actionV3, 0, len(rows))
+			for _, row := range rows {
+				actions = append(actions, &actionV3{
+					Model:       row.Model,
+					Pair:        row.Pair,
+					InPut:       row.InPut,
+					OutPut:      row.OutPut,
+					Gas:         row.Gas,
+					Price:       row.Price,
+					Profit:      row.Profit,
+					TxHash:      row.TxHash,
+					IncludeSake: row.IncludeSake,
+				})
+			}
+			return tx.Create(&actions).Error
+		}).Error
+	})
+}
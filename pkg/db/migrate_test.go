@@ -0,0 +1,163 @@
+package db
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func schemaVersionOf(t *testing.T, db *gorm.DB) int {
+	version, err := SchemaVersion(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return version
+}
+
+func TestMigrate(t *testing.T) {
+	database, cleanup := openTestDB(t)
+	defer cleanup()
+
+	plan, err := Migrate(database, -1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.From != 0 || plan.To != LatestVersion() || len(plan.Steps) != LatestVersion() || plan.Down() {
+		t.Fatalf("plan %+v", plan)
+	}
+	if database.Migrator().HasTable(&schemaVersion{}) || database.Migrator().HasTable(actionTable) {
+		t.Fatal("dry run changed the schema")
+	}
+
+	if _, err := Migrate(database, -1, false); err != nil {
+		t.Fatal(err)
+	}
+	if v := schemaVersionOf(t, database); v != LatestVersion() {
+		t.Fatalf("version %d", v)
+	}
+	// the models match the latest schema
+	action := &InchAction{Pair: "QLC/QGAS", InPut: NewAmount(big.NewInt(150000000)), Profit: decimal(t, "0.1"), TxHash: "a"}
+	if err := InsertSwapResult(database, action); err != nil {
+		t.Fatal(err)
+	}
+	if decimals, err := TokenDecimals(database, "QGAS"); err != nil || decimals != 8 {
+		t.Fatalf("QGAS decimals %d, %v", decimals, err)
+	}
+
+	// down to the float schema and up again keeps the actions
+	plan, err = Migrate(database, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Down() || len(plan.Steps) != LatestVersion()-1 || plan.Steps[0].Version != LatestVersion() {
+		t.Fatalf("plan %+v", plan)
+	}
+	var legacy actionV1
+	if err := database.First(&legacy, action.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if legacy.InPut != "1.5" || legacy.Profit != 0.1 || legacy.Price != "" {
+		t.Fatalf("reverted %+v", legacy)
+	}
+	if database.Migrator().HasTable(&tokenV2{}) {
+		t.Fatal("tokens kept")
+	}
+	if v := schemaVersionOf(t, database); v != 1 {
+		t.Fatalf("version %d", v)
+	}
+
+	if _, err := Migrate(database, -1, false); err != nil {
+		t.Fatal(err)
+	}
+	a, err := GetInchActionByTxHash(database, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.InPut.String() != "150000000" || a.Profit.String() != "0.1" || a.Status != TxUnknown {
+		t.Fatalf("migrated %+v", a)
+	}
+
+	if _, err := Migrate(database, LatestVersion()+1, false); err == nil {
+		t.Fatal("migrated to an unknown version")
+	}
+}
+
+func TestMigrateExactTypes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "actions.db")
+
+	// the schema before versions were recorded and amounts and decimals were exact
+	legacy, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := legacy.AutoMigrate(&actionV1{}); err != nil {
+		t.Fatal(err)
+	}
+	rows := []*actionV1{
+		{Pair: "QLC/QGAS", InPut: "1.5", OutPut: "20", Price: "13.33333333", Profit: 0.1, TxHash: "a"},
+		{Pair: "QGAS/QLC", InPut: "0.00000001", Profit: -2.25, TxHash: "b"},
+		// written in units
+		{Pair: "QLC/QGAS", InPut: "250000000", OutPut: "3", TxHash: "c"},
+	}
+	if err := legacy.Create(&rows).Error; err != nil {
+		t.Fatal(err)
+	}
+	if v := schemaVersionOf(t, legacy); v != 1 {
+		t.Fatalf("detected version %d", v)
+	}
+	if sqlDB, err := legacy.DB(); err == nil {
+		sqlDB.Close()
+	}
+
+	database, err := NewDB(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if sqlDB, err := database.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}()
+	a, err := GetInchActionByTxHash(database, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.ID != rows[0].ID || a.InPut.String() != "150000000" || a.OutPut.String() != "2000000000" ||
+		a.Price.String() != "13.33333333" || a.Profit.String() != "0.1" {
+		t.Errorf("converted %+v", a)
+	}
+	b, err := GetInchActionByTxHash(database, "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.InPut.String() != "1" || b.OutPut.Sign() != 0 || b.Profit.String() != "-2.25" {
+		t.Errorf("converted %+v", b)
+	}
+	c, err := GetInchActionByTxHash(database, "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.InPut.String() != "250000000" || c.OutPut.String() != "300000000" {
+		t.Errorf("converted %+v", c)
+	}
+	var applied []*schemaVersion
+	if err := database.Order("version").Find(&applied).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != LatestVersion() {
+		t.Errorf("applied %d migrations", len(applied))
+	}
+	if database.Migrator().HasTable(actionTable + "_previous") {
+		t.Error("previous table kept")
+	}
+}
@@ -0,0 +1,255 @@
+package db
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Sort orders of actions, ties are broken by id.
+const (
+	SortByTime   = "time"
+	SortByProfit = "profit"
+	SortByGas    = "gas"
+)
+
+var sortColumns = map[string]string{
+	SortByTime:   "created_at",
+	SortByProfit: "profit",
+	SortByGas:    "gas",
+}
+
+// ErrInvalidCursor is returned for cursors which are malformed or belong to another order.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ActionQuery selects and orders actions. Zero values don't filter. Pages are either
+// selected by number or, stable while actions are inserted, by the cursor of the
+// previous page.
+type ActionQuery struct {
+	Pair        string
+	IncludeSake *bool
+	// created at or after Since and before Until
+	Since time.Time
+	Until time.Time
+	// profit within [MinProfit, MaxProfit]
+	MinProfit *Decimal
+	MaxProfit *Decimal
+	HasTxHash *bool
+	// one of the Tx statuses
+	Status string
+
+	// one of the SortBy constants, SortByTime by default
+	SortBy    string
+	Ascending bool
+
+	Page     int
+	PageSize int
+	// continues after the last action of a page, Page is ignored then
+	Cursor string
+}
+
+// cursor is the position after the last action of a page.
+type cursor struct {
+	SortBy    string          `json:"s"`
+	Ascending bool            `json:"a"`
+	Value     json.RawMessage `json:"v"`
+	ID        uint            `json:"id"`
+}
+
+// sortBy returns the order of q and the SQL expression it sorts by.
+func (q *ActionQuery) sortBy(db *gorm.DB) (string, string, error) {
+	sortBy := q.SortBy
+	if sortBy == "" {
+		sortBy = SortByTime
+	}
+	column, ok := sortColumns[sortBy]
+	if !ok {
+		return "", "", fmt.Errorf("unknown sort order %s", q.SortBy)
+	}
+	if sortBy == SortByProfit {
+		column = numeric(db, column)
+	}
+	return sortBy, column, nil
+}
+
+// Filter applies the filters of the query.
+func (q *ActionQuery) Filter(db *gorm.DB) *gorm.DB {
+	if q.Pair != "" {
+		db = db.Where("pair = ?", q.Pair)
+	}
+	if q.IncludeSake != nil {
+		db = db.Where("include_sake = ?", *q.IncludeSake)
+	}
+	if !q.Since.IsZero() {
+		db = db.Where("created_at >= ?", q.Since)
+	}
+	if !q.Until.IsZero() {
+		db = db.Where("created_at < ?", q.Until)
+	}
+	if q.MinProfit != nil {
+		db = db.Where(numeric(db, "profit")+" >= "+numericParam(db), q.MinProfit.String())
+	}
+	if q.MaxProfit != nil {
+		db = db.Where(numeric(db, "profit")+" <= "+numericParam(db), q.MaxProfit.String())
+	}
+	if q.HasTxHash != nil {
+		if *q.HasTxHash {
+			db = db.Where("tx_hash IS NOT NULL AND tx_hash <> ''")
+		} else {
+			db = db.Where("(tx_hash IS NULL OR tx_hash = '')")
+		}
+	}
+	if q.Status != "" {
+		db = db.Where("status = ?", q.Status)
+	}
+	return db
+}
+
+// QueryInchAction returns a page of the actions matching q and the cursor of the next
+// page, which is empty after the last page.
+func QueryInchAction(db *gorm.DB, q *ActionQuery) ([]*InchAction, string, error) {
+	sortBy, column, err := q.sortBy(db)
+	if err != nil {
+		return nil, "", err
+	}
+	dir, cmp := "DESC", "<"
+	if q.Ascending {
+		dir, cmp = "ASC", ">"
+	}
+	page, pageSize := PageParams(q.Page, q.PageSize)
+
+	tx := q.Filter(db.Model(&InchAction{})).Order(fmt.Sprintf("%s %s, id %s", column, dir, dir))
+	if q.Cursor != "" {
+		c, err := decodeCursor(q.Cursor)
+		if err != nil || c.SortBy != sortBy || c.Ascending != q.Ascending {
+			return nil, "", ErrInvalidCursor
+		}
+		value, err := cursorValue(sortBy, c.Value)
+		if err != nil {
+			return nil, "", ErrInvalidCursor
+		}
+		param := "?"
+		if sortBy == SortByProfit {
+			param = numericParam(db)
+		}
+		tx = tx.Where(fmt.Sprintf("(%s %s %s OR (%s = %s AND id %s ?))", column, cmp, param, column, param, cmp), value, value, c.ID)
+	} else {
+		tx = tx.Scopes(Paginate(page, pageSize))
+	}
+
+	var result []*InchAction
+	// fetch one more to learn whether a next page exists
+	if err := tx.Limit(pageSize + 1).Find(&result).Error; err != nil {
+		return nil, "", err
+	}
+	if len(result) <= pageSize {
+		return result, "", nil
+	}
+	result = result[:pageSize]
+	next, err := encodeCursor(sortBy, q.Ascending, result[pageSize-1])
+	if err != nil {
+		return nil, "", err
+	}
+	return result, next, nil
+}
+
+// EachInchAction calls fn with every action matching the filters of q in the order of q,
+// pages are ignored. Actions are read one at a time, fn stops the iteration by returning
+// an error.
+func EachInchAction(db *gorm.DB, q *ActionQuery, fn func(action *InchAction) error) error {
+	_, column, err := q.sortBy(db)
+	if err != nil {
+		return err
+	}
+	dir := "DESC"
+	if q.Ascending {
+		dir = "ASC"
+	}
+	tx := q.Filter(db.Model(&InchAction{})).Order(fmt.Sprintf("%s %s, id %s", column, dir, dir))
+	rows, err := tx.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var action InchAction
+		if err := tx.ScanRows(rows, &action); err != nil {
+			return err
+		}
+		if err := fn(&action); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// CountInchActionQuery returns the number of actions matching the filters of q.
+func CountInchActionQuery(db *gorm.DB, q *ActionQuery) (int64, error) {
+	var count int64
+	if err := q.Filter(db.Model(&InchAction{})).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func encodeCursor(sortBy string, ascending bool, last *InchAction) (string, error) {
+	var value interface{}
+	switch sortBy {
+	case SortByTime:
+		value = last.CreatedAt.Format(time.RFC3339Nano)
+	case SortByProfit:
+		value = last.Profit.String()
+	case SortByGas:
+		value = last.Gas
+	}
+	v, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(&cursor{SortBy: sortBy, Ascending: ascending, Value: v, ID: last.ID})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(s string) (*cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func cursorValue(sortBy string, raw json.RawMessage) (interface{}, error) {
+	switch sortBy {
+	case SortByTime:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		// keeps the zone offset, times are compared in the format they are stored in
+		return time.Parse(time.RFC3339Nano, s)
+	case SortByProfit:
+		var v string
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, err
+		}
+		if _, err := ParseDecimal(v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	default:
+		var v int64
+		err := json.Unmarshal(raw, &v)
+		return v, err
+	}
+}
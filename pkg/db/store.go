@@ -1,7 +1,8 @@
 package db
 
 import (
-	"gorm.io/driver/sqlite"
+	"time"
+
 	"gorm.io/gorm"
 )
 
@@ -10,28 +11,106 @@ const (
 	defaultPageSize = 10
 )
 
-func NewDB(url string) (*gorm.DB, error) {
-	db, err := gorm.Open(sqlite.Open(url), &gorm.Config{})
+// Options tune the connections to a database, zero values keep the defaults. The SQLite
+// settings are ignored by other databases.
+type Options struct {
+	// SQLite journal mode, WAL by default, which lets readers continue while a writer
+	// commits
+	JournalMode string
+	// SQLite synchronous level, NORMAL by default, which is safe with WAL
+	Synchronous string
+	// Time SQLite waits for a lock held by another connection, 5s by default
+	BusyTimeout time.Duration
+
+	// Connection pool limits, unlimited if 0
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// DefaultOptions returns the options used if none are given.
+func DefaultOptions() *Options {
+	return &Options{
+		JournalMode: "WAL",
+		Synchronous: "NORMAL",
+		BusyTimeout: 5 * time.Second,
+	}
+}
+
+// withDefaults returns the options with the defaults for unset values.
+func (o *Options) withDefaults() *Options {
+	opts := DefaultOptions()
+	if o == nil {
+		return opts
+	}
+	result := *o
+	if result.JournalMode == "" {
+		result.JournalMode = opts.JournalMode
+	}
+	if result.Synchronous == "" {
+		result.Synchronous = opts.Synchronous
+	}
+	if result.BusyTimeout == 0 {
+		result.BusyTimeout = opts.BusyTimeout
+	}
+	return &result
+}
+
+// Open opens the database of dsn without migrating it, see ParseDSN for the DSN forms.
+func Open(dsn string, opts *Options) (*gorm.DB, error) {
+	opts = opts.withDefaults()
+	d, err := dialector(dsn, opts)
 	if err != nil {
 		return nil, err
 	}
-	db.AutoMigrate(&InchAction{})
+	db, err := gorm.Open(d, &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(opts.MaxOpenConns)
+	if opts.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(opts.MaxIdleConns)
+	}
+	sqlDB.SetConnMaxLifetime(opts.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(opts.ConnMaxIdleTime)
 	return db, nil
 }
 
-func Paginate(page, pageSize int) func(db *gorm.DB) *gorm.DB {
-	return func(db *gorm.DB) *gorm.DB {
-		if page == 0 {
-			page = 1
-		}
+// NewDB opens the database of dsn and migrates it to the latest schema version.
+func NewDB(dsn string, opts *Options) (*gorm.DB, error) {
+	db, err := Open(dsn, opts)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := Migrate(db, -1, false); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
 
-		switch {
-		case pageSize > maxPageSize:
-			pageSize = maxPageSize
-		case pageSize <= 0:
-			pageSize = defaultPageSize
-		}
+// PageParams normalizes the page and page size like Paginate does.
+func PageParams(page, pageSize int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
 
+	switch {
+	case pageSize > maxPageSize:
+		pageSize = maxPageSize
+	case pageSize <= 0:
+		pageSize = defaultPageSize
+	}
+	return page, pageSize
+}
+
+func Paginate(page, pageSize int) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		page, pageSize := PageParams(page, pageSize)
 		offset := (page - 1) * pageSize
 		return db.Offset(offset).Limit(pageSize)
 	}
@@ -46,6 +125,74 @@ func GetInchAction(db *gorm.DB, page, pageSize int) ([]*InchAction, error) {
 	}
 }
 
+// CountInchAction returns the number of stored actions.
+func CountInchAction(db *gorm.DB) (int64, error) {
+	var count int64
+	if err := db.Model(&InchAction{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetInchActionByID returns the action with the given id, gorm.ErrRecordNotFound if
+// there is none.
+func GetInchActionByID(db *gorm.DB, id uint) (*InchAction, error) {
+	var result InchAction
+	if err := db.First(&result, id).Error; err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetInchActionByTxHash returns the action of a transaction, gorm.ErrRecordNotFound if
+// there is none.
+func GetInchActionByTxHash(db *gorm.DB, txHash string) (*InchAction, error) {
+	var result InchAction
+	if err := db.Where("tx_hash = ?", txHash).First(&result).Error; err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// InsertSwapResult inserts an action, its transaction is pending unless a status is set.
+// Producers insert through Writer.InsertAction, which passes committed actions to its hook.
 func InsertSwapResult(db *gorm.DB, record *InchAction) error {
+	if record.TxHash != "" && record.Status == "" {
+		record.Status = TxPending
+	}
 	return db.Create(record).Error
 }
+
+// PoolStats are the statistics of the connection pool of a database.
+type PoolStats struct {
+	MaxOpenConnections int `json:"maxOpenConnections"`
+	OpenConnections    int `json:"openConnections"`
+	InUse              int `json:"inUse"`
+	Idle               int `json:"idle"`
+	// connections waited for and the total time waited
+	WaitCount         int64  `json:"waitCount"`
+	WaitDuration      string `json:"waitDuration"`
+	MaxIdleClosed     int64  `json:"maxIdleClosed"`
+	MaxIdleTimeClosed int64  `json:"maxIdleTimeClosed"`
+	MaxLifetimeClosed int64  `json:"maxLifetimeClosed"`
+}
+
+// ConnPoolStats returns the statistics of the connection pool of db.
+func ConnPoolStats(db *gorm.DB) (*PoolStats, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	s := sqlDB.Stats()
+	return &PoolStats{
+		MaxOpenConnections: s.MaxOpenConnections,
+		OpenConnections:    s.OpenConnections,
+		InUse:              s.InUse,
+		Idle:               s.Idle,
+		WaitCount:          s.WaitCount,
+		WaitDuration:       s.WaitDuration.String(),
+		MaxIdleClosed:      s.MaxIdleClosed,
+		MaxIdleTimeClosed:  s.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  s.MaxLifetimeClosed,
+	}, nil
+}
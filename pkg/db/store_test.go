@@ -0,0 +1,335 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// testDatabaseEnv selects the database of the tests, e.g.
+// BEYOND_TEST_DATABASE=postgres://beyond@localhost/beyond_test?sslmode=disable. Its
+// tables are dropped. The tests use a temporary SQLite file if it is unset.
+const testDatabaseEnv = "BEYOND_TEST_DATABASE"
+
+// openTestDB returns an empty database without migrations.
+func openTestDB(t *testing.T) (*gorm.DB, func()) {
+	if dsn := os.Getenv(testDatabaseEnv); dsn != "" {
+		database, err := Open(dsn, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reset := func() {
+			if _, err := Migrate(database, 0, false); err != nil {
+				t.Fatal(err)
+			}
+			if err := database.Migrator().DropTable(&schemaVersion{}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		reset()
+		return database, func() {
+			reset()
+			if sqlDB, err := database.DB(); err == nil {
+				sqlDB.Close()
+			}
+		}
+	}
+
+	dir, err := ioutil.TempDir("", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	database, err := Open(filepath.Join(dir, "actions.db"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return database, func() {
+		if sqlDB, err := database.DB(); err == nil {
+			sqlDB.Close()
+		}
+		os.RemoveAll(dir)
+	}
+}
+
+func newTestDB(t *testing.T) (*gorm.DB, func()) {
+	database, cleanup := openTestDB(t)
+	if _, err := Migrate(database, -1, false); err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	return database, cleanup
+}
+
+func decimal(t *testing.T, s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func amount(t *testing.T, s string) Amount {
+	a, err := ParseAmount(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestInchActionQueries(t *testing.T) {
+	database, cleanup := newTestDB(t)
+	defer cleanup()
+
+	for _, hash := range []string{"a", "b", "c"} {
+		if err := InsertSwapResult(database, &InchAction{Pair: "QLC/QGAS", TxHash: hash}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	count, err := CountInchAction(database)
+	if err != nil || count != 3 {
+		t.Fatalf("count %d, %v", count, err)
+	}
+	page, err := GetInchAction(database, 2, 2)
+	if err != nil || len(page) != 1 || page[0].TxHash != "c" {
+		t.Fatalf("second page %v, %v", page, err)
+	}
+	action, err := GetInchActionByTxHash(database, "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if byID, err := GetInchActionByID(database, action.ID); err != nil || byID.TxHash != "b" {
+		t.Fatalf("by id %v, %v", byID, err)
+	}
+	if _, err := GetInchActionByTxHash(database, "d"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("missing tx: %v", err)
+	}
+	if p, s := PageParams(-1, 1000); p != 1 || s != maxPageSize {
+		t.Errorf("page params %d, %d", p, s)
+	}
+}
+
+func TestQueryInchAction(t *testing.T) {
+	database, cleanup := newTestDB(t)
+	defer cleanup()
+
+	start := time.Now()
+	for i, profit := range []float64{3, -1, 3, 7, 0} {
+		action := &InchAction{Pair: "QLC/QGAS", Profit: decimal(t, fmt.Sprint(profit)), Gas: int64(10 * i), IncludeSake: i%2 == 0}
+		action.CreatedAt = start.Add(time.Duration(i) * time.Minute)
+		if i == 4 {
+			action.Pair = "QGAS/QLC"
+		} else {
+			action.TxHash = fmt.Sprintf("tx%d", i)
+		}
+		if err := InsertSwapResult(database, action); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	profits := func(actions []*InchAction) []float64 {
+		var p []float64
+		for _, a := range actions {
+			p = append(p, a.Profit.Float64())
+		}
+		return p
+	}
+	yes, min := true, decimal(t, "0")
+
+	// walk the pages by cursor, ties of the sort value are ordered by id
+	q := &ActionQuery{SortBy: SortByProfit, PageSize: 2}
+	var all []float64
+	for {
+		actions, next, err := QueryInchAction(database, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		all = append(all, profits(actions)...)
+		if next == "" {
+			break
+		}
+		// inserts don't shift the following pages
+		if err := InsertSwapResult(database, &InchAction{Profit: decimal(t, "100")}); err != nil {
+			t.Fatal(err)
+		}
+		q.Cursor = next
+	}
+	if fmt.Sprint(all) != "[7 3 3 0 -1]" {
+		t.Errorf("profits by cursor %v", all)
+	}
+
+	q = &ActionQuery{Pair: "QLC/QGAS", Ascending: true, PageSize: 3}
+	first, next, err := QueryInchAction(database, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.Cursor = next
+	rest, _, err := QueryInchAction(database, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(profits(append(first, rest...))); got != "[3 -1 3 7]" {
+		t.Errorf("profits by time cursor %s", got)
+	}
+
+	for _, tc := range []struct {
+		q    *ActionQuery
+		want string
+	}{
+		{&ActionQuery{Pair: "QLC/QGAS", Ascending: true}, "[3 -1 3 7]"},
+		{&ActionQuery{IncludeSake: &yes, MinProfit: &min, Since: start.Add(time.Minute), Until: start.Add(time.Hour)}, "[0 3]"},
+		{&ActionQuery{HasTxHash: &yes, SortBy: SortByGas, PageSize: 1, Page: 2}, "[3]"},
+	} {
+		actions, _, err := QueryInchAction(database, tc.q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fmt.Sprint(profits(actions)); got != tc.want {
+			t.Errorf("query %+v: profits %s, want %s", tc.q, got, tc.want)
+		}
+	}
+	if count, err := CountInchActionQuery(database, &ActionQuery{Pair: "QLC/QGAS"}); err != nil || count != 4 {
+		t.Errorf("count %d, %v", count, err)
+	}
+	if _, _, err := QueryInchAction(database, &ActionQuery{SortBy: SortByGas, Cursor: "bad"}); err != ErrInvalidCursor {
+		t.Errorf("bad cursor: %v", err)
+	}
+}
+
+func TestActionAnalytics(t *testing.T) {
+	database, cleanup := newTestDB(t)
+	defer cleanup()
+
+	// monday 2020-11-02 10:30 UTC
+	start := time.Date(2020, 11, 2, 10, 30, 0, 0, time.UTC)
+	for i, action := range []*InchAction{
+		{Pair: "QLC/QGAS", Profit: decimal(t, "2"), Gas: 10},
+		{Pair: "QLC/QGAS", Profit: decimal(t, "-1"), Gas: 20},
+		{Pair: "QGAS/QLC", Profit: decimal(t, "4"), Gas: 30},
+		{Pair: "QLC/QGAS", Profit: decimal(t, "3"), Gas: 40},
+	} {
+		action.CreatedAt = start.Add(time.Duration(i) * 24 * time.Hour)
+		if err := InsertSwapResult(database, action); err != nil {
+			t.Fatal(err)
+		}
+	}
+	deleted := &InchAction{Pair: "QLC/QGAS", Profit: decimal(t, "100")}
+	if err := InsertSwapResult(database, deleted); err != nil {
+		t.Fatal(err)
+	}
+	if err := database.Delete(deleted).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := ActionStats(database, &ActionQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "{Trades:4 TotalProfit:8 AverageProfit:2 MedianProfit:2.5 Gas:100 Wins:3 BestProfit:4 WorstProfit:-1}"
+	if got := fmt.Sprintf("%+v", *stats); got != want {
+		t.Errorf("stats %s, want %s", got, want)
+	}
+
+	pairs, err := ActionStatsByPair(database, &ActionQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pairs) != 2 || pairs[1].Pair != "QLC/QGAS" || pairs[1].Trades != 3 || pairs[1].MedianProfit.String() != "2" || pairs[1].WinRate() != 2.0/3 {
+		t.Errorf("pairs %+v", pairs)
+	}
+
+	days, err := PnLSeries(database, &ActionQuery{Pair: "QLC/QGAS"}, BucketDay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, p := range days {
+		got = append(got, fmt.Sprintf("%s %s %s", p.Time.Format("01-02"), p.TotalProfit, p.CumulativeProfit))
+	}
+	if fmt.Sprint(got) != "[11-02 2 2 11-03 -1 1 11-05 3 4]" {
+		t.Errorf("daily pnl %v", got)
+	}
+
+	weeks, err := PnLSeries(database, &ActionQuery{}, BucketWeek)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(weeks) != 1 || !weeks[0].Time.Equal(time.Date(2020, 11, 2, 0, 0, 0, 0, time.UTC)) || weeks[0].Trades != 4 {
+		t.Errorf("weekly pnl %+v", weeks)
+	}
+}
+
+// Profits which are equal as floats compare, sort and add up exactly.
+func TestExactProfits(t *testing.T) {
+	database, cleanup := newTestDB(t)
+	defer cleanup()
+
+	for _, profit := range []string{"0.1", "0.2", "0.10000000000000000001", "-0.00000000000000000001"} {
+		if err := InsertSwapResult(database, &InchAction{Pair: "QLC/QGAS", Profit: decimal(t, profit)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	min := decimal(t, "0.10000000000000000001")
+	actions, _, err := QueryInchAction(database, &ActionQuery{MinProfit: &min, SortBy: SortByProfit, Ascending: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 2 || actions[0].Profit.Cmp(min) != 0 {
+		t.Errorf("profits from %s: %v", min, actions)
+	}
+	stats, err := ActionStats(database, &ActionQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "{Trades:4 TotalProfit:0.4 AverageProfit:0.1 MedianProfit:0.100000000000000000005 " +
+		"Gas:0 Wins:3 BestProfit:0.2 WorstProfit:-0.00000000000000000001}"
+	if got := fmt.Sprintf("%+v", *stats); got != want {
+		t.Errorf("stats %s, want %s", got, want)
+	}
+}
+
+func TestTxStatus(t *testing.T) {
+	database, cleanup := newTestDB(t)
+	defer cleanup()
+
+	actions := []*InchAction{{Pair: "QLC/QGAS", TxHash: "a"}, {Pair: "QLC/QGAS"}, {Pair: "QLC/QGAS", TxHash: "c"}}
+	for _, a := range actions {
+		if err := InsertSwapResult(database, a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if actions[0].Status != TxPending || actions[1].Status != "" {
+		t.Fatalf("statuses %q %q", actions[0].Status, actions[1].Status)
+	}
+	pending, err := PendingTransactions(database)
+	if err != nil || len(pending) != 2 || pending[0].ID != actions[0].ID {
+		t.Fatalf("pending %d: %v", len(pending), err)
+	}
+
+	confirmedAt := time.Unix(1600000000, 0)
+	if ok, err := SetTxStatus(database, actions[0].ID, TxConfirmed, &confirmedAt, 21); err != nil || !ok {
+		t.Fatalf("set status %v: %v", ok, err)
+	}
+	// final statuses are kept
+	if ok, err := SetTxStatus(database, actions[0].ID, TxDropped, nil, 0); err != nil || ok {
+		t.Fatalf("set final status %v: %v", ok, err)
+	}
+	if _, err := SetTxStatus(database, actions[2].ID, "lost", nil, 0); err == nil {
+		t.Fatal("set an unknown status")
+	}
+	a, err := GetInchActionByID(database, actions[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Status != TxConfirmed || a.ConfirmedAt == nil || !a.ConfirmedAt.Equal(confirmedAt) || a.GasUsed != 21 {
+		t.Fatalf("action %+v", a)
+	}
+	if n, err := CountInchActionQuery(database, &ActionQuery{Status: TxPending}); err != nil || n != 1 {
+		t.Fatalf("%d pending: %v", n, err)
+	}
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultDecimals are the decimals of tokens without metadata, QLC chain tokens use 8.
+const DefaultDecimals = 8
+
+// Token is the metadata of a traded token.
+type Token struct {
+	Symbol   string `gorm:"primaryKey" json:"symbol"`
+	Decimals uint8  `json:"decimals"`
+}
+
+// defaultTokens are stored when the table is created.
+var defaultTokens = []*Token{
+	{Symbol: "QLC", Decimals: 8},
+	{Symbol: "QGAS", Decimals: 8},
+}
+
+// TokenDecimals returns the decimals of symbol, DefaultDecimals if it is unknown.
+func TokenDecimals(db *gorm.DB, symbol string) (uint8, error) {
+	var token Token
+	err := db.Where("symbol = ?", symbol).First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return DefaultDecimals, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return token.Decimals, nil
+}
+
+// SaveToken inserts or updates the metadata of a token.
+func SaveToken(db *gorm.DB, token *Token) error {
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "symbol"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"decimals": token.Decimals}),
+	}).Create(token).Error
+}
+
+// PairTokens splits a pair like QLC/QGAS into the input and the output token.
+func PairTokens(pair string) (string, string) {
+	i := strings.IndexByte(pair, '/')
+	if i < 0 {
+		return pair, pair
+	}
+	return pair[:i], pair[i+1:]
+}
+
+// PairDecimals returns the decimals of the input and the output token of pair.
+func PairDecimals(db *gorm.DB, pair string) (uint8, uint8, error) {
+	in, out := PairTokens(pair)
+	inDecimals, err := TokenDecimals(db, in)
+	if err != nil {
+		return 0, 0, err
+	}
+	outDecimals, err := TokenDecimals(db, out)
+	if err != nil {
+		return 0, 0, err
+	}
+	return inDecimals, outDecimals, nil
+}
+
+// Tokens returns the metadata of all tokens.
+func Tokens(db *gorm.DB) ([]*Token, error) {
+	var tokens []*Token
+	if err := db.Order("symbol").Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Statuses of the transaction of an action.
+const (
+	// sent, not confirmed yet
+	TxPending = "pending"
+	// confirmed on the chain, final
+	TxConfirmed = "confirmed"
+	// rejected by the chain, final
+	TxFailed = "failed"
+	// not confirmed in time, final
+	TxDropped = "dropped"
+	// recorded before transactions were tracked, never checked
+	TxUnknown = "unknown"
+)
+
+// ValidTxStatus checks whether status is one of the Tx statuses.
+func ValidTxStatus(status string) error {
+	switch status {
+	case TxPending, TxConfirmed, TxFailed, TxDropped, TxUnknown:
+		return nil
+	default:
+		return fmt.Errorf("unknown transaction status %s", status)
+	}
+}
+
+// PendingTransactions returns the actions whose transaction is pending, the oldest first.
+func PendingTransactions(db *gorm.DB) ([]*InchAction, error) {
+	var result []*InchAction
+	if err := db.Where("status = ? AND tx_hash <> ''", TxPending).Order("id").Find(&result).Error; err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SetTxStatus records the final status of the pending transaction of an action. It
+// reports whether the action was pending, the action is left alone otherwise.
+func SetTxStatus(db *gorm.DB, id uint, status string, confirmedAt *time.Time, gasUsed int64) (bool, error) {
+	if err := ValidTxStatus(status); err != nil {
+		return false, err
+	}
+	result := db.Model(&InchAction{}).Where("id = ? AND status = ?", id, TxPending).Updates(map[string]interface{}{
+		"status":       status,
+		"confirmed_at": confirmedAt,
+		"gas_used":     gasUsed,
+	})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,110 @@
+package db
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestDecimal(t *testing.T) {
+	for in, want := range map[string]string{
+		"1.50":     "1.5",
+		"-0.05":    "-0.05",
+		"1.25e-3":  "0.00125",
+		"12e2":     "1200",
+		"0.000":    "0",
+		"+3":       "3",
+		"-.5":      "-0.5",
+		"10000000": "10000000",
+	} {
+		d, err := ParseDecimal(in)
+		if err != nil {
+			t.Fatalf("%s: %s", in, err)
+		}
+		if d.String() != want {
+			t.Errorf("%s: got %s, want %s", in, d, want)
+		}
+	}
+	for _, in := range []string{"", ".", "1.2.3", "abc", "1e", "0x10", "1_000"} {
+		if _, err := ParseDecimal(in); err == nil {
+			t.Errorf("%q accepted", in)
+		}
+	}
+
+	a, b := decimal(t, "0.1"), decimal(t, "0.2")
+	if sum := a.Add(b); sum.String() != "0.3" {
+		t.Errorf("0.1 + 0.2 = %s", sum)
+	}
+	if diff := a.Sub(b); diff.String() != "-0.1" || diff.Sign() != -1 {
+		t.Errorf("0.1 - 0.2 = %s", diff)
+	}
+	if prod := a.Mul(decimal(t, "-30")); prod.String() != "-3" {
+		t.Errorf("0.1 * -30 = %s", prod)
+	}
+	if a.Cmp(b) != -1 || b.Cmp(decimal(t, "0.20")) != 0 {
+		t.Error("compare")
+	}
+	for _, c := range []struct {
+		d     string
+		n     int64
+		scale int32
+		want  string
+	}{
+		{"1", 3, 4, "0.3333"},
+		{"2", 3, 4, "0.6667"},
+		{"-2", 3, 4, "-0.6667"},
+		{"0.5", 2, 2, "0.25"},
+		{"0.25", 2, 2, "0.13"},
+		{"-0.25", 2, 2, "-0.13"},
+		{"7", 2, 0, "4"},
+	} {
+		if q := decimal(t, c.d).Quo(c.n, c.scale); q.String() != c.want {
+			t.Errorf("%s / %d = %s, want %s", c.d, c.n, q, c.want)
+		}
+	}
+	if f, _ := DecimalFromFloat(0.1); f.String() != "0.1" {
+		t.Errorf("from float %s", f)
+	}
+
+	data, _ := json.Marshal(decimal(t, "2.5"))
+	if string(data) != `"2.5"` {
+		t.Errorf("json %s", data)
+	}
+	var d Decimal
+	if err := json.Unmarshal([]byte(`1.75`), &d); err != nil || d.String() != "1.75" {
+		t.Errorf("json number %s, %v", d, err)
+	}
+	for _, src := range []interface{}{"-4.2", []byte("-4.2"), -4.2} {
+		var d Decimal
+		if err := d.Scan(src); err != nil || d.String() != "-4.2" {
+			t.Errorf("scan %T: %s, %v", src, d, err)
+		}
+	}
+}
+
+func TestAmount(t *testing.T) {
+	n, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	a := NewAmount(n)
+	v, err := a.Value()
+	if err != nil || v != "123456789012345678901234567890" {
+		t.Fatalf("value %v, %v", v, err)
+	}
+	var b Amount
+	if err := b.Scan([]byte(v.(string))); err != nil || b.Cmp(a) != 0 {
+		t.Fatalf("scan %s, %v", b, err)
+	}
+
+	units, err := AmountFromDecimal(decimal(t, "1.5"), 8)
+	if err != nil || units.String() != "150000000" {
+		t.Fatalf("units %s, %v", units, err)
+	}
+	if d := units.Decimal(8); d.String() != "1.5" {
+		t.Errorf("tokens %s", d)
+	}
+	if _, err := AmountFromDecimal(decimal(t, "0.123456789"), 8); err == nil {
+		t.Error("fraction of a unit accepted")
+	}
+	if sum := units.Add(units).Sub(NewAmount(big.NewInt(1))); sum.String() != "299999999" {
+		t.Errorf("sum %s", sum)
+	}
+}
@@ -0,0 +1,244 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultWriteBatchSize = 100
+	DefaultWriteQueueSize = 1000
+)
+
+// ErrWriterStopped is returned for writes after the writer stopped.
+var ErrWriterStopped = errors.New("database writer stopped")
+
+// Writer runs the writes to a database on one goroutine, so concurrent writers don't
+// compete for the SQLite write lock. Writes queued while a transaction commits are
+// committed together in the next transaction, each in a savepoint, so a failed write
+// doesn't abort the others.
+type Writer struct {
+	db        *gorm.DB
+	batchSize int
+	queue     chan *write
+
+	mu       sync.RWMutex
+	stopped  bool
+	onAction func(action *InchAction)
+	quit     chan struct{}
+	done     chan struct{}
+
+	writes   uint64
+	failed   uint64
+	batches  uint64
+	maxBatch uint64
+}
+
+type write struct {
+	ctx context.Context
+	fn  func(tx *gorm.DB) error
+	err chan error
+}
+
+// WriterStats count the writes since the writer started.
+type WriterStats struct {
+	// writes waiting in the queue
+	Queued    int    `json:"queued"`
+	QueueSize int    `json:"queueSize"`
+	Writes    uint64 `json:"writes"`
+	Failed    uint64 `json:"failed"`
+	// committed transactions
+	Batches  uint64 `json:"batches"`
+	MaxBatch uint64 `json:"maxBatch"`
+}
+
+// NewWriter creates a writer committing at most batchSize writes in a transaction, at
+// most queueSize writes wait, further writers block. Zero sizes select the defaults.
+func NewWriter(db *gorm.DB, batchSize, queueSize int) *Writer {
+	if batchSize <= 0 {
+		batchSize = DefaultWriteBatchSize
+	}
+	if queueSize <= 0 {
+		queueSize = DefaultWriteQueueSize
+	}
+	return &Writer{
+		db:        db,
+		batchSize: batchSize,
+		queue:     make(chan *write, queueSize),
+		quit:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Start runs the writer.
+func (w *Writer) Start() {
+	go w.run()
+}
+
+// Stop commits the queued writes and stops the writer.
+func (w *Writer) Stop() {
+	w.mu.Lock()
+	if w.stopped {
+		w.mu.Unlock()
+		return
+	}
+	w.stopped = true
+	close(w.quit)
+	w.mu.Unlock()
+	<-w.done
+}
+
+// Write runs fn in a transaction of the writer and returns its error. Writes are skipped
+// if ctx is done before they start, a write which already started is committed even if
+// ctx is done before it returns.
+func (w *Writer) Write(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	wr := &write{ctx: ctx, fn: fn, err: make(chan error, 1)}
+
+	w.mu.RLock()
+	if w.stopped {
+		w.mu.RUnlock()
+		return ErrWriterStopped
+	}
+	select {
+	case w.queue <- wr:
+	case <-ctx.Done():
+		w.mu.RUnlock()
+		return ctx.Err()
+	}
+	w.mu.RUnlock()
+
+	select {
+	case err := <-wr.err:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SetActionHook sets the function InsertAction passes the committed actions to.
+func (w *Writer) SetActionHook(fn func(action *InchAction)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.onAction = fn
+}
+
+// InsertAction inserts an action through the writer. Producers of actions insert them
+// here, once the transaction of the action is committed it's passed to the hook set by
+// SetActionHook. Actions whose writes return an error are not passed, even if ctx was
+// done after they were committed.
+func (w *Writer) InsertAction(ctx context.Context, action *InchAction) error {
+	err := w.Write(ctx, func(tx *gorm.DB) error {
+		return InsertSwapResult(tx, action)
+	})
+	if err != nil {
+		return err
+	}
+	w.mu.RLock()
+	fn := w.onAction
+	w.mu.RUnlock()
+	if fn != nil {
+		fn(action)
+	}
+	return nil
+}
+
+// DB returns the database of the writer, for reads.
+func (w *Writer) DB() *gorm.DB {
+	return w.db
+}
+
+// Stats returns the counters of the writer.
+func (w *Writer) Stats() *WriterStats {
+	return &WriterStats{
+		Queued:    len(w.queue),
+		QueueSize: cap(w.queue),
+		Writes:    atomic.LoadUint64(&w.writes),
+		Failed:    atomic.LoadUint64(&w.failed),
+		Batches:   atomic.LoadUint64(&w.batches),
+		MaxBatch:  atomic.LoadUint64(&w.maxBatch),
+	}
+}
+
+func (w *Writer) run() {
+	defer close(w.done)
+	for {
+		select {
+		case wr := <-w.queue:
+			w.commit(w.fill(wr))
+		case <-w.quit:
+			// writers can't queue anymore, commit what is left
+			for {
+				select {
+				case wr := <-w.queue:
+					w.commit(w.fill(wr))
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// fill adds the queued writes to a batch starting with first.
+func (w *Writer) fill(first *write) []*write {
+	batch := []*write{first}
+	for len(batch) < w.batchSize {
+		select {
+		case wr := <-w.queue:
+			batch = append(batch, wr)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+func (w *Writer) commit(batch []*write) {
+	pending := batch[:0]
+	for _, wr := range batch {
+		if err := wr.ctx.Err(); err != nil {
+			wr.err <- err
+		} else {
+			pending = append(pending, wr)
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	errs := make([]error, len(pending))
+	err := w.db.Transaction(func(tx *gorm.DB) error {
+		for i, wr := range pending {
+			name := fmt.Sprintf("write%d", i)
+			if err := tx.SavePoint(name).Error; err != nil {
+				return err
+			}
+			if errs[i] = wr.fn(tx); errs[i] != nil {
+				if err := tx.RollbackTo(name).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+
+	atomic.AddUint64(&w.batches, 1)
+	if n := uint64(len(pending)); n > atomic.LoadUint64(&w.maxBatch) {
+		atomic.StoreUint64(&w.maxBatch, n)
+	}
+	for i, wr := range pending {
+		if err != nil {
+			errs[i] = err
+		}
+		atomic.AddUint64(&w.writes, 1)
+		if errs[i] != nil {
+			atomic.AddUint64(&w.failed, 1)
+		}
+		wr.err <- errs[i]
+	}
+}
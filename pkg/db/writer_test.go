@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestWriter(t *testing.T) {
+	database, cleanup := newTestDB(t)
+	defer cleanup()
+	w := NewWriter(database, 10, 0)
+	var hookMu sync.Mutex
+	var hooked []*InchAction
+	w.SetActionHook(func(action *InchAction) {
+		hookMu.Lock()
+		defer hookMu.Unlock()
+		// the action is committed before it's passed to the hook
+		if _, err := GetInchActionByID(database, action.ID); err != nil {
+			t.Errorf("hooked action %d: %v", action.ID, err)
+		}
+		hooked = append(hooked, action)
+	})
+	w.Start()
+
+	const writers = 50
+	fail := errors.New("fail")
+	var wg sync.WaitGroup
+	errs := make([]error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%10 == 0 {
+				// rolled back without aborting the other writes of the batch
+				errs[i] = w.Write(context.Background(), func(tx *gorm.DB) error {
+					if err := InsertSwapResult(tx, &InchAction{Pair: "QLC/QGAS", TxHash: "rolled back"}); err != nil {
+						return err
+					}
+					return fail
+				})
+				return
+			}
+			errs[i] = w.InsertAction(context.Background(), &InchAction{Pair: "QLC/QGAS", TxHash: fmt.Sprint(i)})
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if i%10 == 0 && err != fail || i%10 != 0 && err != nil {
+			t.Errorf("write %d: %v", i, err)
+		}
+	}
+	count, err := CountInchAction(database)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != writers-writers/10 {
+		t.Errorf("%d actions", count)
+	}
+	if len(hooked) != int(count) {
+		t.Errorf("%d actions passed to the hook, want %d", len(hooked), count)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := w.InsertAction(ctx, &InchAction{}); err != context.Canceled {
+		t.Errorf("canceled write: %v", err)
+	}
+
+	w.Stop()
+	stats := w.Stats()
+	if stats.Writes != writers || stats.Failed != writers/10 || stats.Batches == 0 || stats.MaxBatch > 10 {
+		t.Errorf("stats %+v", stats)
+	}
+	if err := w.InsertAction(context.Background(), &InchAction{}); err != ErrWriterStopped {
+		t.Errorf("write after stop: %v", err)
+	}
+}
+
+func TestSQLiteOptions(t *testing.T) {
+	if os.Getenv(testDatabaseEnv) != "" {
+		t.Skip("not a SQLite database")
+	}
+	database, cleanup := newTestDB(t)
+	defer cleanup()
+
+	var mode string
+	var timeout, synchronous int
+	database.Raw("PRAGMA journal_mode").Scan(&mode)
+	database.Raw("PRAGMA busy_timeout").Scan(&timeout)
+	database.Raw("PRAGMA synchronous").Scan(&synchronous)
+	if mode != "wal" || timeout != 5000 || synchronous != 1 {
+		t.Errorf("journal mode %s, busy timeout %d, synchronous %d", mode, timeout, synchronous)
+	}
+
+	opts := DefaultOptions()
+	for source, want := range map[string]string{
+		"actions.db":                   "actions.db?_journal_mode=WAL&_synchronous=NORMAL&_busy_timeout=5000&_txlock=immediate",
+		"file:actions.db?_journal=OFF": "file:actions.db?_journal=OFF&_synchronous=NORMAL&_busy_timeout=5000&_txlock=immediate",
+		"actions.db?_busy_timeout=100": "actions.db?_busy_timeout=100&_journal_mode=WAL&_synchronous=NORMAL&_txlock=immediate",
+	} {
+		if got := sqliteSource(source, opts); got != want {
+			t.Errorf("%s: %s", source, got)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package event
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrSlowConsumer is sent to subscribers which don't keep up with the feed. They can
+	// resubscribe from the last sequence they received.
+	ErrSlowConsumer = errors.New("consumer too slow")
+	// ErrSequenceExpired is returned when resuming from a sequence which is no longer
+	// in the history of the feed.
+	ErrSequenceExpired = errors.New("sequence no longer available")
+	// ErrFeedClosed is sent to subscribers when the feed is closed.
+	ErrFeedClosed = errors.New("feed closed")
+)
+
+const (
+	DefaultHistory = 1024
+	DefaultBuffer  = 256
+)
+
+// Event is a published value with its position in the feed.
+type Event struct {
+	Seq  uint64
+	Time time.Time
+	Data interface{}
+}
+
+// Feed is an in-memory, sequenced event log. It keeps a bounded history so subscribers
+// can resume after reconnecting. Publishing never blocks, subscribers which can't keep
+// up are dropped with ErrSlowConsumer. Sequences start at 1 and restart with the process.
+type Feed struct {
+	mu      sync.Mutex
+	seq     uint64
+	history []*Event // ring buffer of the last events
+	next    int
+	full    bool
+	buffer  int
+	subs    map[*Subscription]struct{}
+	closed  bool
+}
+
+// NewFeed creates a feed keeping history events for resumption, each subscriber may
+// lag behind by buffer events.
+func NewFeed(history, buffer int) *Feed {
+	if history <= 0 {
+		history = DefaultHistory
+	}
+	if buffer <= 0 {
+		buffer = DefaultBuffer
+	}
+	return &Feed{
+		history: make([]*Event, history),
+		buffer:  buffer,
+		subs:    make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish appends data to the feed and returns its sequence.
+func (f *Feed) Publish(data interface{}) uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return 0
+	}
+	f.seq++
+	e := &Event{Seq: f.seq, Time: time.Now(), Data: data}
+	f.history[f.next] = e
+	f.next = (f.next + 1) % len(f.history)
+	if f.next == 0 {
+		f.full = true
+	}
+	for s := range f.subs {
+		select {
+		case s.ch <- e:
+		default:
+			f.drop(s, ErrSlowConsumer)
+		}
+	}
+	return e.Seq
+}
+
+// Seq returns the sequence of the last published event.
+func (f *Feed) Seq() uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.seq
+}
+
+// Subscribe returns the events published after the sequence from, which are still in
+// the history, and a subscription receiving the events published from now on. If from
+// is 0 only new events are received.
+func (f *Feed) Subscribe(from uint64) ([]*Event, *Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.subscribe(from)
+}
+
+// SubscribeLatest is like Subscribe(0), but the backlog holds the last published event,
+// for feeds where the last event is the current state.
+func (f *Feed) SubscribeLatest() ([]*Event, *Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	backlog, sub, err := f.subscribe(0)
+	if err == nil && f.seq > 0 {
+		events := f.events()
+		backlog = events[len(events)-1:]
+	}
+	return backlog, sub, err
+}
+
+func (f *Feed) subscribe(from uint64) ([]*Event, *Subscription, error) {
+	if f.closed {
+		return nil, nil, ErrFeedClosed
+	}
+	var backlog []*Event
+	if from > f.seq {
+		// sequences restart with the process
+		return nil, nil, ErrSequenceExpired
+	}
+	if from > 0 && from < f.seq {
+		events := f.events()
+		if len(events) == 0 || events[0].Seq > from+1 {
+			return nil, nil, ErrSequenceExpired
+		}
+		backlog = events[from+1-events[0].Seq:]
+	}
+	s := &Subscription{feed: f, ch: make(chan *Event, f.buffer), err: make(chan error, 1)}
+	f.subs[s] = struct{}{}
+	return backlog, s, nil
+}
+
+// Close ends all subscriptions with ErrFeedClosed.
+func (f *Feed) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.closed = true
+	for s := range f.subs {
+		f.drop(s, ErrFeedClosed)
+	}
+}
+
+// events returns the history in publishing order.
+func (f *Feed) events() []*Event {
+	if !f.full {
+		return append([]*Event(nil), f.history[:f.next]...)
+	}
+	return append(append([]*Event(nil), f.history[f.next:]...), f.history[:f.next]...)
+}
+
+func (f *Feed) drop(s *Subscription, err error) {
+	delete(f.subs, s)
+	if err != nil {
+		s.err <- err
+	}
+}
+
+// Subscription receives the events of a feed.
+type Subscription struct {
+	feed *Feed
+	ch   chan *Event
+	err  chan error
+	once sync.Once
+}
+
+// Events returns the channel delivering the events.
+func (s *Subscription) Events() <-chan *Event {
+	return s.ch
+}
+
+// Err returns a channel receiving the reason when the feed drops the subscription.
+func (s *Subscription) Err() <-chan error {
+	return s.err
+}
+
+// Unsubscribe stops the delivery of events.
+func (s *Subscription) Unsubscribe() {
+	s.once.Do(func() {
+		s.feed.mu.Lock()
+		defer s.feed.mu.Unlock()
+		if _, ok := s.feed.subs[s]; ok {
+			s.feed.drop(s, nil)
+		}
+	})
+}
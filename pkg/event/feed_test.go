@@ -0,0 +1,94 @@
+package event
+
+import (
+	"testing"
+)
+
+func TestFeedResume(t *testing.T) {
+	f := NewFeed(4, 8)
+	for i := 0; i < 6; i++ {
+		f.Publish(i)
+	}
+
+	backlog, sub, err := f.Subscribe(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+	if len(backlog) != 3 || backlog[0].Seq != 4 || backlog[2].Seq != 6 || backlog[2].Data != 5 {
+		t.Fatalf("wrong backlog %v", backlog)
+	}
+	f.Publish(6)
+	if e := <-sub.Events(); e.Seq != 7 {
+		t.Fatalf("got sequence %d, want 7", e.Seq)
+	}
+
+	if _, _, err := f.Subscribe(1); err != ErrSequenceExpired {
+		t.Fatalf("got %v, want ErrSequenceExpired", err)
+	}
+	if _, _, err := f.Subscribe(100); err != ErrSequenceExpired {
+		t.Fatalf("got %v, want ErrSequenceExpired", err)
+	}
+	backlog, live, err := f.Subscribe(0)
+	if err != nil || len(backlog) != 0 {
+		t.Fatalf("got %v %v, want no backlog", backlog, err)
+	}
+	live.Unsubscribe()
+}
+
+func TestFeedSubscribeLatest(t *testing.T) {
+	f := NewFeed(4, 4)
+	backlog, sub, err := f.SubscribeLatest()
+	if err != nil || len(backlog) != 0 {
+		t.Fatalf("got %v %v, want no backlog", backlog, err)
+	}
+	sub.Unsubscribe()
+
+	f.Publish("a")
+	f.Publish("b")
+	backlog, sub, err = f.SubscribeLatest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+	if len(backlog) != 1 || backlog[0].Data != "b" {
+		t.Fatalf("wrong backlog %v", backlog)
+	}
+}
+
+func TestFeedSlowConsumer(t *testing.T) {
+	f := NewFeed(16, 2)
+	_, sub, err := f.Subscribe(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		f.Publish(i)
+	}
+	if err := <-sub.Err(); err != ErrSlowConsumer {
+		t.Fatalf("got %v, want ErrSlowConsumer", err)
+	}
+	// the buffered events are still delivered, the consumer resumes after the last one
+	<-sub.Events()
+	last := (<-sub.Events()).Seq
+	backlog, resumed, err := f.Subscribe(last)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resumed.Unsubscribe()
+	if len(backlog) != 1 || backlog[0].Seq != 3 {
+		t.Fatalf("wrong backlog %v", backlog)
+	}
+}
+
+func TestFeedClose(t *testing.T) {
+	f := NewFeed(0, 0)
+	_, sub, _ := f.Subscribe(0)
+	f.Close()
+	if err := <-sub.Err(); err != ErrFeedClosed {
+		t.Fatalf("got %v, want ErrFeedClosed", err)
+	}
+	if _, _, err := f.Subscribe(0); err != ErrFeedClosed {
+		t.Fatalf("got %v, want ErrFeedClosed", err)
+	}
+}
@@ -0,0 +1,227 @@
+// Package export writes the swap history as CSV, JSON Lines or Parquet.
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/drip/beyond/pkg/db"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+	"gorm.io/gorm"
+)
+
+// Formats of an export.
+const (
+	FormatCSV     = "csv"
+	FormatJSONL   = "jsonl"
+	FormatParquet = "parquet"
+)
+
+// parquetRowGroupSize bounds the rows Parquet buffers before they are written.
+const parquetRowGroupSize = 8 * 1024 * 1024
+
+// Record is an exported action. Amounts are given in tokens and in the smallest unit of
+// the tokens, decimals are exact. ConfirmedAt is set once the transaction confirmed.
+type Record struct {
+	ID          int64      `json:"id" parquet:"name=id, type=INT64"`
+	CreatedAt   time.Time  `json:"created_at"`
+	Time        int64      `json:"-" parquet:"name=created_at, type=TIMESTAMP_MILLIS"`
+	Pair        string     `json:"pair" parquet:"name=pair, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Input       string     `json:"input" parquet:"name=input, type=UTF8"`
+	Output      string     `json:"output" parquet:"name=output, type=UTF8"`
+	InputUnits  string     `json:"input_units" parquet:"name=input_units, type=UTF8"`
+	OutputUnits string     `json:"output_units" parquet:"name=output_units, type=UTF8"`
+	Gas         int64      `json:"gas" parquet:"name=gas, type=INT64"`
+	Price       string     `json:"price" parquet:"name=price, type=UTF8"`
+	Profit      string     `json:"profit" parquet:"name=profit, type=UTF8"`
+	TxHash      string     `json:"tx_hash" parquet:"name=tx_hash, type=UTF8"`
+	IncludeSake bool       `json:"include_sake" parquet:"name=include_sake, type=BOOLEAN"`
+	Status      string     `json:"status" parquet:"name=status, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+	Confirmed   *int64     `json:"-" parquet:"name=confirmed_at, type=TIMESTAMP_MILLIS, repetitiontype=OPTIONAL"`
+	GasUsed     int64      `json:"gas_used" parquet:"name=gas_used, type=INT64"`
+}
+
+// header are the CSV columns, in the order of Record.
+var header = []string{"id", "created_at", "pair", "input", "output", "input_units", "output_units", "gas",
+	"price", "profit", "tx_hash", "include_sake", "status", "confirmed_at", "gas_used"}
+
+func (r *Record) csv() []string {
+	confirmedAt := ""
+	if r.ConfirmedAt != nil {
+		confirmedAt = r.ConfirmedAt.Format(time.RFC3339Nano)
+	}
+	return []string{
+		strconv.FormatInt(r.ID, 10),
+		r.CreatedAt.Format(time.RFC3339Nano),
+		r.Pair,
+		r.Input,
+		r.Output,
+		r.InputUnits,
+		r.OutputUnits,
+		strconv.FormatInt(r.Gas, 10),
+		r.Price,
+		r.Profit,
+		r.TxHash,
+		strconv.FormatBool(r.IncludeSake),
+		r.Status,
+		confirmedAt,
+		strconv.FormatInt(r.GasUsed, 10),
+	}
+}
+
+// ContentType returns the media type of format.
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatJSONL:
+		return "application/x-ndjson"
+	default:
+		return "application/vnd.apache.parquet"
+	}
+}
+
+// ValidFormat checks whether format is known.
+func ValidFormat(format string) error {
+	switch format {
+	case FormatCSV, FormatJSONL, FormatParquet:
+		return nil
+	default:
+		return fmt.Errorf("unknown export format %s", format)
+	}
+}
+
+// encoder writes records in a format.
+type encoder interface {
+	encode(r *Record) error
+	// close writes buffered records and the trailer of the format
+	close() error
+}
+
+func newEncoder(format string, w io.Writer) (encoder, error) {
+	switch format {
+	case FormatCSV:
+		e := &csvEncoder{w: csv.NewWriter(w)}
+		if err := e.w.Write(header); err != nil {
+			return nil, err
+		}
+		return e, nil
+	case FormatJSONL:
+		return &jsonlEncoder{enc: json.NewEncoder(w)}, nil
+	case FormatParquet:
+		pw, err := writer.NewParquetWriterFromWriter(w, new(Record), 1)
+		if err != nil {
+			return nil, err
+		}
+		pw.RowGroupSize = parquetRowGroupSize
+		pw.CompressionType = parquet.CompressionCodec_SNAPPY
+		return &parquetEncoder{w: pw}, nil
+	default:
+		return nil, ValidFormat(format)
+	}
+}
+
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvEncoder) encode(r *Record) error {
+	return e.w.Write(r.csv())
+}
+
+func (e *csvEncoder) close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type jsonlEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *jsonlEncoder) encode(r *Record) error {
+	return e.enc.Encode(r)
+}
+
+func (e *jsonlEncoder) close() error {
+	return nil
+}
+
+type parquetEncoder struct {
+	w *writer.ParquetWriter
+}
+
+func (e *parquetEncoder) encode(r *Record) error {
+	return e.w.Write(r)
+}
+
+func (e *parquetEncoder) close() error {
+	return e.w.WriteStop()
+}
+
+// Actions writes the actions matching the filters of q to w in the order of q and returns
+// the number of actions written. Actions are streamed from the database, Parquet buffers
+// a row group at most. The output is incomplete if an error is returned.
+func Actions(ctx context.Context, database *gorm.DB, q *db.ActionQuery, format string, w io.Writer) (int64, error) {
+	enc, err := newEncoder(format, w)
+	if err != nil {
+		return 0, err
+	}
+	// token metadata is read up front, the query keeps its connection while it streams
+	tokens, err := db.Tokens(database)
+	if err != nil {
+		return 0, err
+	}
+	decimals := make(map[string]uint8, len(tokens))
+	for _, t := range tokens {
+		decimals[t.Symbol] = t.Decimals
+	}
+	tokenDecimals := func(symbol string) uint8 {
+		if d, ok := decimals[symbol]; ok {
+			return d
+		}
+		return db.DefaultDecimals
+	}
+
+	var n int64
+	err = db.EachInchAction(database.WithContext(ctx), q, func(action *db.InchAction) error {
+		in, out := db.PairTokens(action.Pair)
+		r := &Record{
+			ID:          int64(action.ID),
+			CreatedAt:   action.CreatedAt.UTC(),
+			Time:        action.CreatedAt.UnixNano() / int64(time.Millisecond),
+			Pair:        action.Pair,
+			Input:       action.InPut.Decimal(tokenDecimals(in)).String(),
+			Output:      action.OutPut.Decimal(tokenDecimals(out)).String(),
+			InputUnits:  action.InPut.String(),
+			OutputUnits: action.OutPut.String(),
+			Gas:         action.Gas,
+			Price:       action.Price.String(),
+			Profit:      action.Profit.String(),
+			TxHash:      action.TxHash,
+			IncludeSake: action.IncludeSake,
+			Status:      action.Status,
+			GasUsed:     action.GasUsed,
+		}
+		if action.ConfirmedAt != nil {
+			confirmedAt := action.ConfirmedAt.UTC()
+			confirmed := confirmedAt.UnixNano() / int64(time.Millisecond)
+			r.ConfirmedAt, r.Confirmed = &confirmedAt, &confirmed
+		}
+		if err := enc.encode(r); err != nil {
+			return err
+		}
+		n++
+		return nil
+	})
+	if err != nil {
+		return n, err
+	}
+	return n, enc.close()
+}
@@ -0,0 +1,127 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drip/beyond/pkg/db"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+	"gorm.io/gorm"
+)
+
+func newTestDB(t *testing.T) (*gorm.DB, func()) {
+	dir, err := ioutil.TempDir("", "export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	database, err := db.NewDB(filepath.Join(dir, "actions.db"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	price, _ := db.ParseDecimal("13.33333333")
+	for i, profit := range []string{"0.1", "-2.25", "1"} {
+		p, _ := db.ParseDecimal(profit)
+		action := &db.InchAction{
+			Pair:   "QLC/QGAS",
+			InPut:  db.NewAmount(big.NewInt(150000000)),
+			OutPut: db.NewAmount(big.NewInt(int64(2000000000 + i))),
+			Gas:    int64(10 + i),
+			Price:  price,
+			Profit: p,
+			TxHash: "0xtx" + profit,
+		}
+		if err := db.InsertSwapResult(database, action); err != nil {
+			t.Fatal(err)
+		}
+	}
+	confirmedAt := time.Unix(1600000000, 0)
+	if _, err := db.SetTxStatus(database, 3, db.TxConfirmed, &confirmedAt, 21); err != nil {
+		t.Fatal(err)
+	}
+	return database, func() {
+		if sqlDB, err := database.DB(); err == nil {
+			sqlDB.Close()
+		}
+		os.RemoveAll(dir)
+	}
+}
+
+func export(t *testing.T, database *gorm.DB, q *db.ActionQuery, format string) []byte {
+	var buf bytes.Buffer
+	n, err := Actions(context.Background(), database, q, format, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Fatalf("%s: exported %d actions", format, n)
+	}
+	return buf.Bytes()
+}
+
+func TestActions(t *testing.T) {
+	database, cleanup := newTestDB(t)
+	defer cleanup()
+	q := &db.ActionQuery{SortBy: db.SortByProfit, Ascending: true}
+
+	rows, err := csv.NewReader(bytes.NewReader(export(t, database, q, FormatCSV))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 4 || strings.Join(rows[0], ",") != strings.Join(header, ",") {
+		t.Fatalf("csv %v", rows)
+	}
+	if r := rows[1]; r[2] != "QLC/QGAS" || r[3] != "1.5" || r[4] != "20.00000001" || r[5] != "150000000" ||
+		r[8] != "13.33333333" || r[9] != "-2.25" || r[11] != "false" || r[12] != db.TxPending || r[13] != "" {
+		t.Errorf("csv row %v", r)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(export(t, database, q, FormatJSONL))), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("jsonl %v", lines)
+	}
+	var record Record
+	if err := json.Unmarshal([]byte(lines[2]), &record); err != nil {
+		t.Fatal(err)
+	}
+	if record.Profit != "1" || record.Output != "20.00000002" || record.Gas != 12 || record.CreatedAt.IsZero() ||
+		record.Status != db.TxConfirmed || record.ConfirmedAt == nil || record.GasUsed != 21 {
+		t.Errorf("jsonl record %+v", record)
+	}
+
+	file, err := buffer.NewBufferFile(export(t, database, q, FormatParquet))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pr, err := reader.NewParquetReader(file, new(Record), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pr.ReadStop()
+	if pr.GetNumRows() != 3 {
+		t.Fatalf("parquet rows %d", pr.GetNumRows())
+	}
+	records := make([]Record, 3)
+	if err := pr.Read(&records); err != nil {
+		t.Fatal(err)
+	}
+	if r := records[1]; r.Profit != "0.1" || r.Input != "1.5" || r.TxHash != "0xtx0.1" || r.Time == 0 {
+		t.Errorf("parquet record %+v", r)
+	}
+	if r := records[2]; r.Confirmed == nil || *r.Confirmed != 1600000000000 || r.Status != db.TxConfirmed {
+		t.Errorf("parquet record %+v", r)
+	}
+
+	if _, err := Actions(context.Background(), database, q, "xlsx", ioutil.Discard); err == nil {
+		t.Error("unknown format exported")
+	}
+}
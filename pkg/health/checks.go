@@ -0,0 +1,74 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+
+	qlcchain "github.com/qlcchain/qlc-go-sdk"
+)
+
+// DialCheck reports whether a listener accepts connections.
+func DialCheck(network, address string) Check {
+	return func(ctx context.Context) error {
+		conn, err := (&net.Dialer{}).DialContext(ctx, network, address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
+
+// Pinger is implemented by *sql.DB.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// PingCheck reports whether the database is reachable.
+func PingCheck(db Pinger) Check {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// QLCCheck reports whether the QLC node at endpoint answers ledger queries. The client
+// is redialed after failures.
+func QLCCheck(endpoint string) Check {
+	var (
+		mu     sync.Mutex
+		client *qlcchain.QLCClient
+	)
+	return func(ctx context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if client == nil {
+			c, err := qlcchain.NewQLCClient(endpoint)
+			if err != nil {
+				return err
+			}
+			if c == nil {
+				return errors.New("no qlc client")
+			}
+			client = c
+		}
+		result := make(chan error, 1)
+		go func(c *qlcchain.QLCClient) {
+			_, err := c.Ledger.BlocksCount()
+			result <- err
+		}(client)
+
+		var err error
+		select {
+		case err = <-result:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		if err != nil {
+			client.Close()
+			client = nil
+		}
+		return err
+	}
+}
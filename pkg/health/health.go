@@ -0,0 +1,282 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/drip/beyond/pkg/event"
+	"github.com/drip/beyond/pkg/log"
+	"go.uber.org/zap"
+)
+
+const (
+	StatusUnknown = "unknown"
+	StatusUp      = "up"
+	StatusDown    = "down"
+)
+
+const (
+	DefaultInterval = 10 * time.Second
+	DefaultTimeout  = 3 * time.Second
+)
+
+// Check reports the health of a component, a nil error means healthy.
+type Check func(ctx context.Context) error
+
+// Component is the last check result of a component.
+type Component struct {
+	Name string `json:"name"`
+	// Status is unknown until the first check finished
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	// Liveness components are required for the process to be alive, all components are
+	// required for it to be ready
+	Liveness  bool      `json:"liveness"`
+	CheckedAt time.Time `json:"checkedAt"`
+	LatencyMs int64     `json:"latencyMs"`
+}
+
+// Report aggregates the component statuses.
+type Report struct {
+	Live       bool         `json:"live"`
+	Ready      bool         `json:"ready"`
+	Components []*Component `json:"components"`
+}
+
+type component struct {
+	Component
+	check Check
+}
+
+// Monitor checks the registered components periodically. Changes are published as
+// reports to watchers and to the OnChange callbacks.
+type Monitor struct {
+	interval time.Duration
+	timeout  time.Duration
+
+	mu         sync.RWMutex
+	components []*component
+	onChange   []func(Report)
+
+	feed   *event.Feed
+	cancel context.CancelFunc
+	done   chan struct{}
+	logger *zap.SugaredLogger
+}
+
+// NewMonitor creates a monitor running the checks every interval, each limited to timeout.
+func NewMonitor(interval, timeout time.Duration) *Monitor {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Monitor{
+		interval: interval,
+		timeout:  timeout,
+		feed:     event.NewFeed(16, 16),
+		logger:   log.NewLogger("health"),
+	}
+}
+
+// Register adds a component. Liveness components are required for the process to be
+// considered alive, see Report.
+func (m *Monitor) Register(name string, liveness bool, check Check) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.components = append(m.components, &component{
+		Component: Component{Name: name, Status: StatusUnknown, Liveness: liveness},
+		check:     check,
+	})
+}
+
+// OnChange calls fn with the new report whenever the status of a component changes.
+func (m *Monitor) OnChange(fn func(Report)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onChange = append(m.onChange, fn)
+}
+
+// Start runs the checks in the background, the first round immediately.
+func (m *Monitor) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.done = make(chan struct{})
+	go func() {
+		defer close(m.done)
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+		for {
+			m.checkAll(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop ends the checks and the watch streams.
+func (m *Monitor) Stop() {
+	if m.cancel != nil {
+		m.cancel()
+		<-m.done
+	}
+	m.feed.Close()
+}
+
+// Report returns the current status.
+func (m *Monitor) Report() Report {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.report()
+}
+
+func (m *Monitor) report() Report {
+	r := Report{Live: true, Ready: true, Components: make([]*Component, 0, len(m.components))}
+	for _, c := range m.components {
+		cc := c.Component
+		r.Components = append(r.Components, &cc)
+		if cc.Status != StatusUp {
+			r.Ready = false
+		}
+		if cc.Liveness && cc.Status == StatusDown {
+			r.Live = false
+		}
+	}
+	return r
+}
+
+// Watch returns the current report and a subscription receiving a Report on every change.
+func (m *Monitor) Watch() (Report, *event.Subscription, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, sub, err := m.feed.Subscribe(0)
+	return m.report(), sub, err
+}
+
+// checkAll runs all checks concurrently and publishes the report if a status changed.
+func (m *Monitor) checkAll(ctx context.Context) {
+	m.mu.RLock()
+	components := append([]*component(nil), m.components...)
+	m.mu.RUnlock()
+
+	results := make([]Component, len(components))
+	var wg sync.WaitGroup
+	for i, c := range components {
+		wg.Add(1)
+		go func(i int, c *component) {
+			defer wg.Done()
+			results[i] = m.run(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+
+	m.mu.Lock()
+	changed := false
+	for i, c := range components {
+		if c.Status != results[i].Status || c.Error != results[i].Error {
+			changed = true
+			if results[i].Status == StatusDown {
+				m.logger.Warnf("%s is down: %s", c.Name, results[i].Error)
+			} else {
+				m.logger.Infof("%s is %s", c.Name, results[i].Status)
+			}
+		}
+		c.Component = results[i]
+	}
+	report := m.report()
+	onChange := make([]func(Report), len(m.onChange))
+	copy(onChange, m.onChange)
+	m.mu.Unlock()
+
+	if changed {
+		m.feed.Publish(report)
+		for _, fn := range onChange {
+			fn(report)
+		}
+	}
+}
+
+func (m *Monitor) run(ctx context.Context, c *component) Component {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	result := c.Component
+	start := time.Now()
+	err := c.check(ctx)
+	result.CheckedAt = time.Now()
+	result.LatencyMs = int64(result.CheckedAt.Sub(start) / time.Millisecond)
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	} else {
+		result.Status = StatusUp
+		result.Error = ""
+	}
+	return result
+}
+
+// Handler serves the report as JSON, with status 503 if the process isn't live or, for
+// readiness, not ready. With the query parameter watch=true the report is streamed as
+// newline-delimited JSON on every change.
+func (m *Monitor) Handler(readiness bool) http.Handler {
+	ok := func(r Report) bool {
+		if readiness {
+			return r.Ready
+		}
+		return r.Live
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("watch") != "true" {
+			report := m.Report()
+			w.Header().Set("Content-Type", "application/json")
+			if !ok(report) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+			json.NewEncoder(w).Encode(report)
+			return
+		}
+
+		flusher, canFlush := w.(http.Flusher)
+		if !canFlush {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+		report, sub, err := m.Watch()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		defer sub.Unsubscribe()
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("X-Accel-Buffering", "no")
+		if !ok(report) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		enc := json.NewEncoder(w)
+		for {
+			if err := enc.Encode(report); err != nil {
+				return
+			}
+			flusher.Flush()
+			select {
+			case e := <-sub.Events():
+				report = e.Data.(Report)
+			case <-sub.Err():
+				return
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+}
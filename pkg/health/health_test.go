@@ -0,0 +1,69 @@
+package health
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMonitor(t *testing.T) {
+	var dbDown int32
+	m := NewMonitor(10*time.Millisecond, time.Second)
+	m.Register("qlc", false, func(ctx context.Context) error { return errors.New("unreachable") })
+	m.Register("database", true, func(ctx context.Context) error {
+		if atomic.LoadInt32(&dbDown) == 1 {
+			return errors.New("locked")
+		}
+		return nil
+	})
+	if r := m.Report(); !r.Live || r.Ready || r.Components[0].Status != StatusUnknown {
+		t.Fatalf("unexpected report before the first check %+v", r)
+	}
+
+	changes := make(chan Report, 16)
+	m.OnChange(func(r Report) { changes <- r })
+	m.Start()
+	defer m.Stop()
+
+	r := <-changes
+	if !r.Live || r.Ready || r.Components[0].Error != "unreachable" || r.Components[1].Status != StatusUp {
+		t.Fatalf("unexpected report %+v", r)
+	}
+
+	srv := httptest.NewServer(m.Handler(false))
+	defer srv.Close()
+	resp, err := http.Get(srv.URL + "?watch=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("liveness status %d, want 200", resp.StatusCode)
+	}
+
+	atomic.StoreInt32(&dbDown, 1)
+	lines := bufio.NewScanner(resp.Body)
+	for i := 0; i < 2; i++ {
+		if !lines.Scan() {
+			t.Fatal(lines.Err())
+		}
+		if err := json.Unmarshal(lines.Bytes(), &r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if r.Live || r.Components[1].Error != "locked" {
+		t.Fatalf("unexpected report %+v", r)
+	}
+
+	ready := httptest.NewRecorder()
+	m.Handler(true).ServeHTTP(ready, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if ready.Code != http.StatusServiceUnavailable {
+		t.Fatalf("readiness status %d, want 503", ready.Code)
+	}
+}
@@ -0,0 +1,60 @@
+package rpc
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// AuthConfig holds the credentials accepted by an endpoint. Requests must carry either
+// one of the bearer tokens or the basic auth credentials of one of the users.
+type AuthConfig struct {
+	Tokens []string          // accepted bearer tokens
+	Users  map[string]string // accepted basic auth user names and passwords
+}
+
+// authHandler rejects requests without valid credentials.
+type authHandler struct {
+	auth *AuthConfig
+	next http.Handler
+}
+
+// newAuthHandler wraps next with an authHandler. If auth is nil, next is returned.
+func newAuthHandler(auth *AuthConfig, next http.Handler) http.Handler {
+	if auth == nil {
+		return next
+	}
+	return &authHandler{auth: auth, next: next}
+}
+
+// ServeHTTP checks the credentials before passing the request on.
+func (h *authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="rpc"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+func (h *authHandler) authorized(r *http.Request) bool {
+	if user, pass, ok := r.BasicAuth(); ok {
+		want, exist := h.auth.Users[user]
+		return exist && equal(pass, want)
+	}
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return false
+	}
+	token := strings.TrimPrefix(header, "Bearer ")
+	for _, t := range h.auth.Tokens {
+		if equal(token, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
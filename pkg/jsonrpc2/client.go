@@ -27,6 +27,8 @@ import (
 	"strconv"
 	"sync/atomic"
 	"time"
+
+	"github.com/drip/beyond/pkg/util"
 )
 
 var (
@@ -76,6 +78,7 @@ type Client struct {
 	idgen    func() ID // for subscriptions
 	isHTTP   bool
 	services *serviceRegistry
+	session  *session // set if the client serves a server-side connection
 
 	idCounter uint32
 
@@ -111,6 +114,8 @@ type clientConn struct {
 func (c *Client) newClientConn(conn ServerCodec) *clientConn {
 	ctx := context.WithValue(context.Background(), clientContextKey{}, c)
 	handler := newHandler(ctx, conn, c.idgen, c.services)
+	handler.session = c.session
+	c.session.setHandler(handler)
 	return &clientConn{conn, handler}
 }
 
@@ -147,10 +152,14 @@ func (op *requestOp) wait(ctx context.Context, c *Client) (*jsonrpcMessage, erro
 
 // Dial creates a new client for the given URL.
 //
-// The currently supported URL schemes are "http", "https", "ws" and "wss". If rawurl is a
-// file name with no URL scheme, a local socket connection is established using UNIX
-// domain sockets on supported platforms and named pipes on Windows. If you want to
-// configure transport options, use DialHTTP, DialWebsocket or DialIPC instead.
+// The currently supported URL schemes are "http", "https", "ws", "wss", "unix",
+// "http+unix" and "ws+unix". If rawurl is a file name with no URL scheme, a local socket
+// connection is established using UNIX domain sockets on supported platforms and named
+// pipes on Windows. The unix schemes accept the same forms as the server listeners, e.g.
+// unix:///run/gbeyond.sock or unix:@gbeyond for the linux abstract namespace. Use "unix"
+// for IPC endpoints and "http+unix" or "ws+unix" for HTTP and WebSocket endpoints bound to
+// a unix socket. If you want to configure transport options, use DialHTTP, DialWebsocket
+// or DialIPC instead.
 //
 // For websocket connections, the origin is set to the local host name.
 //
@@ -173,6 +182,18 @@ func DialContext(ctx context.Context, rawurl string) (*Client, error) {
 		return DialHTTP(rawurl)
 	case "ws", "wss":
 		return DialWebsocket(ctx, rawurl, "")
+	case "unix", "http+unix", "ws+unix":
+		path, err := util.SocketPath(rawurl)
+		if err != nil {
+			return nil, err
+		}
+		switch u.Scheme {
+		case "http+unix":
+			return DialHTTPUnix(path)
+		case "ws+unix":
+			return DialWebsocketUnix(ctx, path, "")
+		}
+		return DialIPC(ctx, path)
 	case "stdio":
 		return DialStdIO(ctx)
 	case "":
@@ -183,7 +204,8 @@ func DialContext(ctx context.Context, rawurl string) (*Client, error) {
 }
 
 // Client retrieves the client from the context, if any. This can be used to perform
-// 'reverse calls' in a handler method.
+// 'reverse calls' in a handler method. There is no client for HTTP requests because they
+// can't carry calls from the server.
 func ClientFromContext(ctx context.Context) (*Client, bool) {
 	client, ok := ctx.Value(clientContextKey{}).(*Client)
 	return client, ok
@@ -194,17 +216,18 @@ func newClient(initctx context.Context, connect reconnectFunc) (*Client, error)
 	if err != nil {
 		return nil, err
 	}
-	c := initClient(conn, randomIDGenerator(), new(serviceRegistry))
+	c := initClient(conn, randomIDGenerator(), new(serviceRegistry), nil)
 	c.reconnectFunc = connect
 	return c, nil
 }
 
-func initClient(conn ServerCodec, idgen func() ID, services *serviceRegistry) *Client {
+func initClient(conn ServerCodec, idgen func() ID, services *serviceRegistry, sess *session) *Client {
 	_, isHTTP := conn.(*httpConn)
 	c := &Client{
 		idgen:       idgen,
 		isHTTP:      isHTTP,
 		services:    services,
+		session:     sess,
 		writeConn:   conn,
 		close:       make(chan struct{}),
 		closing:     make(chan struct{}),
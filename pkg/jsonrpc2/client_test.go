@@ -19,11 +19,13 @@ package rpc
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"math/rand"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"reflect"
 	"runtime"
 	"sync"
@@ -31,6 +33,7 @@ import (
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/drip/beyond/pkg/util"
 )
 
 func TestClientRequest(t *testing.T) {
@@ -108,6 +111,46 @@ func TestClientNotify(t *testing.T) {
 	}
 }
 
+// Handlers call methods of the client through ClientFromContext.
+func TestClientReverseCall(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+	if err := server.RegisterName("peer", new(peerTestService)); err != nil {
+		t.Fatal(err)
+	}
+	client := DialInProc(server)
+	defer client.Close()
+	if err := client.RegisterName("agent", new(agentTestService)); err != nil {
+		t.Fatal(err)
+	}
+
+	var sig string
+	if err := client.Call(&sig, "peer_sign", "swap"); err != nil {
+		t.Fatal(err)
+	}
+	if sig != "signed:swap" {
+		t.Errorf("incorrect result %q", sig)
+	}
+}
+
+// HTTP requests carry no client for reverse calls.
+func TestClientReverseCallHTTP(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+	if err := server.RegisterName("peer", new(peerTestService)); err != nil {
+		t.Fatal(err)
+	}
+	client, hs := httpTestClient(server, "http", nil)
+	defer hs.Close()
+	defer client.Close()
+
+	var sig string
+	err := client.Call(&sig, "peer_sign", "swap")
+	if err == nil || err.Error() != errNoReverseCalls.Error() {
+		t.Fatalf("expected %v, got %v", errNoReverseCalls, err)
+	}
+}
+
 // func TestClientCancelInproc(t *testing.T) { testClientCancel("inproc", t) }
 func TestClientCancelWebsocket(t *testing.T) { testClientCancel("ws", t) }
 func TestClientCancelHTTP(t *testing.T)      { testClientCancel("http", t) }
@@ -563,3 +606,57 @@ func (l *flakeyListener) Accept() (net.Conn, error) {
 	}
 	return c, err
 }
+
+func TestClientDialUnix(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix sockets not supported")
+	}
+	server := newTestServer()
+	defer server.Stop()
+
+	dir, err := ioutil.TempDir("", "go-ethereum-test-unix")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ipc, err := util.Listen("unix://"+filepath.Join(dir, "ipc.sock"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ipc.Close()
+	go server.ServeListener(ipc)
+
+	h, err := util.Listen("unix://"+filepath.Join(dir, "http.sock"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	go NewHTTPServer(nil, []string{"*"}, HTTPTimeouts{}, server).Serve(h)
+
+	ws, err := util.Listen("unix://"+filepath.Join(dir, "ws.sock"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	go NewWSServer([]string{"*"}, server).Serve(ws)
+
+	for _, rawurl := range []string{
+		"unix://" + filepath.Join(dir, "ipc.sock"),
+		"http+unix://" + filepath.Join(dir, "http.sock"),
+		"ws+unix://" + filepath.Join(dir, "ws.sock"),
+	} {
+		client, err := Dial(rawurl)
+		if err != nil {
+			t.Fatalf("%s: %v", rawurl, err)
+		}
+		var resp Result
+		if err := client.Call(&resp, "test_echo", "hello", 10, &Args{"world"}); err != nil {
+			t.Fatalf("%s: %v", rawurl, err)
+		}
+		if !reflect.DeepEqual(resp, Result{"hello", 10, &Args{"world"}}) {
+			t.Errorf("%s: incorrect result %#v", rawurl, resp)
+		}
+		client.Close()
+	}
+}
@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"net/http"
+	"strings"
+)
+
+// combinedHandler serves websocket upgrade requests with ws and all other requests
+// with http.
+type combinedHandler struct {
+	prefix string
+	http   http.Handler
+	ws     http.Handler
+}
+
+// CombinedHandler returns a handler that serves JSON-RPC to WebSocket clients and HTTP
+// clients on the same port. Requests carrying websocket upgrade headers are upgraded,
+// plain requests are served like ServeHTTP, restricted to cfg.Cors and cfg.Vhosts.
+//
+// If cfg.Prefix is set, only requests for the prefix and paths below it are served,
+// which allows mounting the handler on an existing mux. Credentials are checked if
+// cfg.Auth is set. Like for StartHTTPEndpoint, they are checked inside the CORS
+// handler, so CORS preflight requests are answered without credentials.
+func (s *Server) CombinedHandler(cfg *EndpointConfig) http.Handler {
+	return &combinedHandler{
+		prefix: strings.TrimSuffix(cfg.Prefix, "/"),
+		http:   newVHostHandler(cfg.Vhosts, newCorsHandler(newAuthHandler(cfg.Auth, s), cfg.Cors)),
+		ws:     newAuthHandler(cfg.Auth, s.WebsocketHandler(cfg.Cors)),
+	}
+}
+
+// ServeHTTP dispatches the request by its upgrade headers.
+func (h *combinedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.matches(r.URL.Path) {
+		http.NotFound(w, r)
+		return
+	}
+	if isWebsocket(r) {
+		h.ws.ServeHTTP(w, r)
+		return
+	}
+	h.http.ServeHTTP(w, r)
+}
+
+// matches reports whether path is the prefix or below it.
+func (h *combinedHandler) matches(path string) bool {
+	if h.prefix == "" || path == h.prefix {
+		return true
+	}
+	return strings.HasPrefix(path, h.prefix+"/")
+}
+
+// isWebsocket reports whether r asks for a websocket upgrade.
+func isWebsocket(r *http.Request) bool {
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		return false
+	}
+	for _, token := range strings.Split(r.Header.Get("Connection"), ",") {
+		if strings.EqualFold(strings.TrimSpace(token), "upgrade") {
+			return true
+		}
+	}
+	return false
+}
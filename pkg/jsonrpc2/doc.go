@@ -114,5 +114,22 @@ Reverse Calls
 In any method handler, an instance of rpc.Client can be accessed through the
 ClientFromContext method. Using this client instance, server-to-client method calls can be
 performed on the RPC connection.
+
+The client invokes the methods the other side has registered through Client.RegisterName,
+e.g. to ask a signing agent attached over IPC to sign a transaction:
+
+ func (s *SwapService) Submit(ctx context.Context, tx *Tx) (string, error) {
+ 	client, ok := rpc.ClientFromContext(ctx)
+ 	if !ok {
+ 		return "", errors.New("no reverse calls over HTTP")
+ 	}
+ 	var sig string
+ 	if err := client.CallContext(ctx, &sig, "agent_sign", tx); err != nil {
+ 		return "", err
+ 	}
+ 	...
+ }
+
+Reverse calls are not available on HTTP connections.
 */
 package rpc
@@ -17,79 +17,105 @@
 package rpc
 
 import (
+	"crypto/tls"
 	"net"
-	"net/url"
+	"net/http"
+
+	"github.com/drip/beyond/pkg/util"
 )
 
-// StartHTTPEndpoint starts the HTTP RPC endpoint, configured with cors/vhosts/modules
-func StartHTTPEndpoint(endpoint string, apis []API, modules []string, cors []string, vhosts []string, timeouts HTTPTimeouts) (net.Listener, *Server, error) {
-	// Generate the whitelist based on the allowed modules
-	whitelist := make(map[string]bool)
-	for _, module := range modules {
-		whitelist[module] = true
-	}
-	// Register all the APIs exposed by the services
-	handler := NewServer()
-	for _, api := range apis {
-		if whitelist[api.Namespace] || (len(whitelist) == 0 && api.Public) {
-			if err := handler.RegisterName(api.Namespace, api.Service); err != nil {
-				return nil, nil, err
-			}
-			logger.Debug("HTTP registered ", "namespace ", api.Namespace)
-		}
+// EndpointConfig holds the settings of an HTTP or WebSocket endpoint.
+type EndpointConfig struct {
+	Modules   []string            // API whitelist, only public APIs are exposed if empty
+	ExposeAll bool                // expose all APIs regardless of Modules
+	Cors      []string            // allowed CORS origins, websocket origins for WebSocket endpoints
+	Vhosts    []string            // allowed virtual hosts, HTTP only
+	Timeouts  HTTPTimeouts        // HTTP only
+	TLS       *tls.Config         // serve TLS if set
+	Auth      *AuthConfig         // require credentials if set
+	Socket    *util.SocketOptions // permissions of unix socket endpoints
+	Prefix    string              // path prefix of combined endpoints, e.g. /rpc
+}
+
+// StartHTTPEndpoint starts the HTTP RPC endpoint, configured with cors/vhosts/modules.
+func StartHTTPEndpoint(endpoint string, apis []API, cfg *EndpointConfig) (net.Listener, *Server, error) {
+	handler, err := NewEndpointServer(apis, cfg)
+	if err != nil {
+		return nil, nil, err
 	}
 	// All APIs registered, start the HTTP listener
-	var (
-		listener net.Listener
-		err      error
-	)
-	network, address, err := scheme(endpoint)
+	listener, err := listen(endpoint, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	go NewHTTPServer(cfg.Cors, cfg.Vhosts, cfg.Timeouts, newAuthHandler(cfg.Auth, handler)).Serve(listener)
+	return listener, handler, err
+}
+
+// StartWSEndpoint starts a websocket endpoint.
+func StartWSEndpoint(endpoint string, apis []API, cfg *EndpointConfig) (net.Listener, *Server, error) {
+	handler, err := NewEndpointServer(apis, cfg)
 	if err != nil {
 		return nil, nil, err
 	}
-	if listener, err = net.Listen(network, address); err != nil {
+	// All APIs registered, start the HTTP listener
+	listener, err := listen(endpoint, cfg)
+	if err != nil {
 		return nil, nil, err
 	}
 
-	go NewHTTPServer(cors, vhosts, timeouts, handler).Serve(listener)
+	srv := &http.Server{Handler: newAuthHandler(cfg.Auth, handler.WebsocketHandler(cfg.Cors))}
+	go srv.Serve(listener)
 	return listener, handler, err
 }
 
-// StartWSEndpoint starts a websocket endpoint
-func StartWSEndpoint(endpoint string, apis []API, modules []string, wsOrigins []string, exposeAll bool) (net.Listener, *Server, error) {
-
+// NewEndpointServer creates a server exposing the APIs allowed by cfg.
+func NewEndpointServer(apis []API, cfg *EndpointConfig) (*Server, error) {
 	// Generate the whitelist based on the allowed modules
 	whitelist := make(map[string]bool)
-	for _, module := range modules {
+	for _, module := range cfg.Modules {
 		whitelist[module] = true
 	}
 	// Register all the APIs exposed by the services
 	handler := NewServer()
 	for _, api := range apis {
-		if exposeAll || whitelist[api.Namespace] || (len(whitelist) == 0 && api.Public) {
+		if cfg.ExposeAll || whitelist[api.Namespace] || (len(whitelist) == 0 && api.Public) {
 			if err := handler.RegisterName(api.Namespace, api.Service); err != nil {
-				return nil, nil, err
+				return nil, err
 			}
-			logger.Debug("WebSocket registered ", " service ", api.Service, " namespace ", api.Namespace)
+			logger.Debug("RPC registered ", "namespace ", api.Namespace)
 		}
 	}
-	// All APIs registered, start the HTTP listener
-	var (
-		listener net.Listener
-		err      error
-	)
-	network, address, err := scheme(endpoint)
+	return handler, nil
+}
+
+// listen opens the listener of an endpoint, wrapped in TLS if configured.
+func listen(endpoint string, cfg *EndpointConfig) (net.Listener, error) {
+	listener, err := util.Listen(endpoint, cfg.Socket)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+	if cfg.TLS != nil {
+		listener = tls.NewListener(listener, cfg.TLS)
 	}
+	return listener, nil
+}
 
-	if listener, err = net.Listen(network, address); err != nil {
+// StartCombinedEndpoint starts an endpoint serving both HTTP and WebSocket clients on
+// the same port, see Server.CombinedHandler.
+func StartCombinedEndpoint(endpoint string, apis []API, cfg *EndpointConfig) (net.Listener, *Server, error) {
+	handler, err := NewEndpointServer(apis, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	listener, err := listen(endpoint, cfg)
+	if err != nil {
 		return nil, nil, err
 	}
 
-	go NewWSServer(wsOrigins, handler).Serve(listener)
-	return listener, handler, err
-
+	go newHTTPServer(cfg.Timeouts, handler.CombinedHandler(cfg)).Serve(listener)
+	return listener, handler, nil
 }
 
 // StartIPCEndpoint starts an IPC endpoint.
@@ -110,11 +136,3 @@ func StartIPCEndpoint(ipcEndpoint string, apis []API) (net.Listener, *Server, er
 	go handler.ServeListener(listener)
 	return listener, handler, nil
 }
-
-func scheme(endpoint string) (string, string, error) {
-	u, err := url.Parse(endpoint)
-	if err != nil {
-		return "", "", err
-	}
-	return u.Scheme, u.Host, nil
-}
@@ -19,6 +19,7 @@ package rpc
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"os"
 	"reflect"
@@ -61,6 +62,7 @@ type handler struct {
 	conn           jsonWriter                     // where responses will be sent
 	log            Logger
 	allowSubscribe bool
+	session        *session // connection statistics, nil for client connections
 
 	subLock    sync.Mutex
 	serverSubs map[ID]*Subscription
@@ -200,6 +202,7 @@ func (h *handler) addSubscriptions(nn []*Notifier) {
 	for _, n := range nn {
 		if sub := n.takeSubscription(); sub != nil {
 			h.serverSubs[sub.ID] = sub
+			h.session.addSubscriptions(1)
 		}
 	}
 }
@@ -213,15 +216,41 @@ func (h *handler) cancelServerSubscriptions(err error) {
 		s.err <- err
 		close(s.err)
 		delete(h.serverSubs, id)
+		h.session.addSubscriptions(-1)
 	}
 }
 
+// notifySubscriptionsClosed sends a close notice with the given reason to all
+// subscribers. It returns the number of notified subscriptions.
+func (h *handler) notifySubscriptionsClosed(reason string) int {
+	h.subLock.Lock()
+	defer h.subLock.Unlock()
+
+	// The notices are best effort, don't let a stalled peer hold up the shutdown.
+	ctx, cancel := context.WithTimeout(h.rootCtx, closeNoticeTimeout)
+	defer cancel()
+	for id, s := range h.serverSubs {
+		params, _ := json.Marshal(&subscriptionClosed{ID: string(id), Reason: reason})
+		err := h.conn.Write(ctx, &jsonrpcMessage{
+			Version: vsn,
+			Method:  s.namespace + closedMethodSuffix,
+			Params:  params,
+		})
+		if err != nil {
+			break
+		}
+	}
+	return len(h.serverSubs)
+}
+
 // startCallProc runs fn in a new goroutine and starts tracking it in the h.calls wait group.
 func (h *handler) startCallProc(fn func(*callProc)) {
 	h.callWG.Add(1)
+	h.session.addInflight(1)
 	go func() {
 		ctx, cancel := context.WithCancel(h.rootCtx)
 		defer h.callWG.Done()
+		defer h.session.addInflight(-1)
 		defer cancel()
 		fn(&callProc{ctx: ctx})
 	}()
@@ -237,6 +266,10 @@ func (h *handler) handleImmediate(msg *jsonrpcMessage) bool {
 			h.handleSubscriptionResult(msg)
 			return true
 		}
+		if strings.HasSuffix(msg.Method, closedMethodSuffix) {
+			h.handleSubscriptionClosed(msg)
+			return true
+		}
 		return false
 	case msg.isResponse():
 		h.handleResponse(msg)
@@ -259,6 +292,19 @@ func (h *handler) handleSubscriptionResult(msg *jsonrpcMessage) {
 	}
 }
 
+// handleSubscriptionClosed ends a client subscription which was closed by the server.
+func (h *handler) handleSubscriptionClosed(msg *jsonrpcMessage) {
+	var closed subscriptionClosed
+	if err := json.Unmarshal(msg.Params, &closed); err != nil {
+		h.log.Debug("Dropping invalid subscription close message")
+		return
+	}
+	if sub := h.clientSubs[closed.ID]; sub != nil {
+		delete(h.clientSubs, closed.ID)
+		sub.quitWithError(errors.New(closed.Reason), false)
+	}
+}
+
 // handleResponse processes method call responses.
 func (h *handler) handleResponse(msg *jsonrpcMessage) {
 	op := h.respWait[string(msg.ID)]
@@ -311,6 +357,9 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 
 // handleCall processes method calls.
 func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+	if h.session.isDraining() && !msg.isUnsubscribe() {
+		return msg.errorResponse(ErrServerShutdown)
+	}
 	if msg.isSubscribe() {
 		return h.handleSubscribe(cp, msg)
 	}
@@ -384,6 +433,7 @@ func (h *handler) unsubscribe(ctx context.Context, id ID) (bool, error) {
 	}
 	close(s.err)
 	delete(h.serverSubs, id)
+	h.session.addSubscriptions(-1)
 	return true, nil
 }
 
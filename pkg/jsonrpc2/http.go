@@ -124,6 +124,17 @@ func DialHTTP(endpoint string) (*Client, error) {
 	return DialHTTPWithClient(endpoint, new(http.Client))
 }
 
+// DialHTTPUnix creates a new RPC client that connects to an HTTP RPC server listening on
+// the unix socket at path.
+func DialHTTPUnix(path string) (*Client, error) {
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialContext(ctx, "unix", path)
+		},
+	}}
+	return DialHTTPWithClient("http://localhost/", client)
+}
+
 func (c *Client) sendHTTP(ctx context.Context, op *requestOp, msg interface{}) error {
 	hc := c.writeConn.(*httpConn)
 	respBody, err := hc.doRequest(ctx, msg)
@@ -215,7 +226,11 @@ func NewHTTPServer(cors []string, vhosts []string, timeouts HTTPTimeouts, srv ht
 	// Wrap the CORS-handler within a host-handler
 	handler := newCorsHandler(srv, cors)
 	handler = newVHostHandler(vhosts, handler)
+	return newHTTPServer(timeouts, handler)
+}
 
+// newHTTPServer creates an HTTP server with sanitized timeouts.
+func newHTTPServer(timeouts HTTPTimeouts, handler http.Handler) *http.Server {
 	// Make sure timeout values are meaningful
 	if timeouts.ReadTimeout < time.Second {
 		//logger.Info("Sanitizing invalid HTTP read timeout ", "provided ", timeouts.ReadTimeout, " updated ", DefaultHTTPTimeouts.ReadTimeout)
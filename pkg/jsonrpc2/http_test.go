@@ -21,6 +21,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestHTTPErrorResponseWithDelete(t *testing.T) {
@@ -52,3 +53,56 @@ func testHTTPErrorResponse(t *testing.T, method, contentType, body string, expec
 		t.Fatalf("response code should be %d not %d", expected, code)
 	}
 }
+
+func TestHTTPEndpointAuth(t *testing.T) {
+	apis := []API{
+		{Namespace: "test", Service: new(testService), Public: true},
+		{Namespace: "private", Service: new(testService)},
+	}
+	cfg := &EndpointConfig{
+		Modules:  []string{"test"},
+		Cors:     []string{"*"},
+		Vhosts:   []string{"*"},
+		Timeouts: DefaultHTTPTimeouts,
+		Auth:     &AuthConfig{Tokens: []string{"secret"}, Users: map[string]string{"admin": "pass"}},
+	}
+	listener, server, err := StartHTTPEndpoint("tcp://127.0.0.1:0", apis, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	defer server.Stop()
+
+	url := "http://" + listener.Addr().String()
+	tests := []struct {
+		method string
+		auth   func(r *http.Request)
+		status int
+	}{
+		{"test_echo", func(r *http.Request) {}, http.StatusUnauthorized},
+		{"test_echo", func(r *http.Request) { r.Header.Set("Authorization", "Bearer wrong") }, http.StatusUnauthorized},
+		{"test_echo", func(r *http.Request) { r.SetBasicAuth("admin", "wrong") }, http.StatusUnauthorized},
+		{"test_echo", func(r *http.Request) { r.Header.Set("Authorization", "Bearer secret") }, http.StatusOK},
+		{"test_echo", func(r *http.Request) { r.SetBasicAuth("admin", "pass") }, http.StatusOK},
+	}
+	client := &http.Client{Timeout: 5 * time.Second}
+	for i, test := range tests {
+		body := `{"jsonrpc":"2.0","id":1,"method":"` + test.method + `","params":["x",1,null]}`
+		req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		test.auth(req)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != test.status {
+			t.Errorf("test %d: status %d, want %d", i, resp.StatusCode, test.status)
+		}
+	}
+
+	// Only whitelisted modules are exposed.
+	if modules := (&RPCService{server}).Modules(); modules["private"] != "" || modules["test"] == "" {
+		t.Errorf("wrong modules exposed: %v", modules)
+	}
+}
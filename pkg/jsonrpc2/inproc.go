@@ -26,7 +26,7 @@ func DialInProc(handler *Server) *Client {
 	initctx := context.Background()
 	c, _ := newClient(initctx, func(context.Context) (ServerCodec, error) {
 		p1, p2 := net.Pipe()
-		go handler.ServeCodec(NewJSONCodec(p1), OptionMethodInvocation|OptionSubscriptions)
+		go handler.serveCodec(NewJSONCodec(p1), TransportInProc, nil)
 		return NewJSONCodec(p2), nil
 	})
 	return c
@@ -23,6 +23,7 @@ import (
 
 // ServeListener accepts connections on l, serving JSON-RPC on them.
 func (s *Server) ServeListener(l net.Listener) error {
+	transport := networkTransport(l.Addr().Network())
 	for {
 		conn, err := l.Accept()
 		if IsTemporaryError(err) {
@@ -32,7 +33,7 @@ func (s *Server) ServeListener(l net.Listener) error {
 			return err
 		}
 		logger.Info("Accepted RPC connection", "conn", conn.RemoteAddr())
-		go s.ServeCodec(NewJSONCodec(conn), OptionMethodInvocation|OptionSubscriptions)
+		go s.serveCodec(NewJSONCodec(conn), transport, nil)
 	}
 }
 
@@ -35,6 +35,7 @@ const (
 	subscribeMethodSuffix    = "_subscribe"
 	unsubscribeMethodSuffix  = "_unsubscribe"
 	notificationMethodSuffix = "_subscription"
+	closedMethodSuffix       = "_subscriptionClosed"
 
 	defaultWriteTimeout = 10 * time.Second // used if context has no deadline
 )
@@ -46,6 +47,13 @@ type subscriptionResult struct {
 	Result json.RawMessage `json:"result,omitempty"`
 }
 
+// subscriptionClosed is the final notification sent to a subscriber before the server
+// closes the connection.
+type subscriptionClosed struct {
+	ID     string `json:"subscription"`
+	Reason string `json:"reason"`
+}
+
 // A value of this type can a JSON-RPC request, notification, successful response or
 // error response. Which one it is depends on the fields.
 type jsonrpcMessage struct {
@@ -18,15 +18,25 @@ package rpc
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"io"
+	"net/http"
+	"sync"
 	"sync/atomic"
-
-	mapset "github.com/deckarep/golang-set"
+	"time"
 )
 
 const MetadataApi = "rpc"
 
+// ErrServerShutdown is returned for calls received while the server is shutting down.
+var ErrServerShutdown = errors.New("server is shutting down")
+
+const (
+	stopPendingRequestTimeout = 3 * time.Second
+	shutdownPollInterval      = 10 * time.Millisecond
+	closeNoticeTimeout        = 500 * time.Millisecond
+)
+
 // CodecOption specifies which type of messages a codec supports.
 //
 // Deprecated: this option is no longer honored by Server.
@@ -45,12 +55,22 @@ type Server struct {
 	services serviceRegistry
 	idgen    func() ID
 	run      int32
-	codecs   mapset.Set
+	sessions *sessionRegistry
+
+	// httpCalls counts the calls of plain HTTP requests, which are too short-lived to be
+	// registered as sessions. It is closed by Shutdown, canceling the calls.
+	httpCalls   *session
+	httpStopped chan struct{}
 }
 
 // NewServer creates a new server instance with no registered handlers.
 func NewServer() *Server {
-	server := &Server{idgen: randomIDGenerator(), codecs: mapset.NewSet(), run: 1}
+	server := &Server{idgen: randomIDGenerator(), sessions: newSessionRegistry(), run: 1}
+	server.httpStopped = make(chan struct{})
+	var stopOnce sync.Once
+	server.httpCalls = server.newSession(TransportHTTP, "", nil, func() {
+		stopOnce.Do(func() { close(server.httpStopped) })
+	})
 	// Register the default service providing meta information about the RPC service such
 	// as the services and methods it offers.
 	rpcService := &RPCService{server}
@@ -72,6 +92,12 @@ func (s *Server) RegisterName(name string, receiver interface{}) error {
 //
 // Note that codec options are no longer supported.
 func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
+	s.serveCodec(codec, codecTransport(codec), nil)
+}
+
+// serveCodec serves codec like ServeCodec. The transport name and the request which
+// opened the connection, if any, are recorded in the session of the connection.
+func (s *Server) serveCodec(codec ServerCodec, transport string, r *http.Request) {
 	defer codec.Close()
 
 	// Don't serve if server is stopped.
@@ -79,11 +105,12 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 		return
 	}
 
-	// Add the codec to the set so it can be closed by Stop.
-	s.codecs.Add(codec)
-	defer s.codecs.Remove(codec)
+	// Register the session so it can be inspected and closed by Stop.
+	sess := s.newSession(transport, codec.RemoteAddr(), r, codec.Close)
+	s.sessions.add(sess)
+	defer s.sessions.remove(sess)
 
-	c := initClient(codec, s.idgen, &s.services)
+	c := initClient(codec, s.idgen, &s.services, sess)
 	<-codec.Closed()
 	c.Close()
 }
@@ -94,11 +121,22 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 	// Don't serve if server is stopped.
 	if atomic.LoadInt32(&s.run) == 0 {
-		fmt.Println("server is stopped")
+		codec.Write(ctx, errorMessage(ErrServerShutdown))
 		return
 	}
 
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-s.httpStopped:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
 	h := newHandler(ctx, codec, s.idgen, &s.services)
+	h.session = s.httpCalls
 	h.allowSubscribe = false
 	defer h.close(io.EOF, nil)
 
@@ -120,13 +158,72 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 // requests to finish, then closes all codecs which will cancel pending requests and
 // subscriptions.
 func (s *Server) Stop() {
-	if atomic.CompareAndSwapInt32(&s.run, 1, 0) {
-		logger.Debug("RPC server shutting down")
-		s.codecs.Each(func(c interface{}) bool {
-			c.(ServerCodec).Close()
-			return true
-		})
+	ctx, cancel := context.WithTimeout(context.Background(), stopPendingRequestTimeout)
+	defer cancel()
+	s.Shutdown(ctx)
+}
+
+// ShutdownReport summarizes the outcome of Shutdown.
+type ShutdownReport struct {
+	Sessions      int   // connections closed
+	Subscriptions int   // subscriptions which received a close notice
+	Aborted       int64 // calls still running when the connections were closed
+}
+
+// Shutdown gracefully stops the server. New connections and calls are rejected, calls
+// in flight are allowed to finish until ctx is done. Subscribers then receive a final
+// close notice and all connections are closed, canceling the calls which are still
+// running.
+func (s *Server) Shutdown(ctx context.Context) ShutdownReport {
+	var report ShutdownReport
+	if !atomic.CompareAndSwapInt32(&s.run, 1, 0) {
+		return report
+	}
+	logger.Debug("RPC server shutting down")
+
+	sessions := s.sessions.all()
+	s.httpCalls.drain()
+	for _, sess := range sessions {
+		sess.drain()
+	}
+
+	ticker := time.NewTicker(shutdownPollInterval)
+	defer ticker.Stop()
+wait:
+	for !idle(sessions) || atomic.LoadInt64(&s.httpCalls.inflight) > 0 {
+		select {
+		case <-ctx.Done():
+			break wait
+		case <-ticker.C:
+		}
+	}
+
+	for _, sess := range sessions {
+		report.Subscriptions += sess.notifyShutdown()
+		report.Aborted += atomic.LoadInt64(&sess.inflight)
+		sess.close()
+	}
+	report.Sessions = len(sessions)
+	report.Aborted += atomic.LoadInt64(&s.httpCalls.inflight)
+	s.httpCalls.close()
+	// Close sessions which registered while the server was stopping.
+	for _, sess := range s.sessions.all() {
+		sess.close()
+	}
+	if report.Aborted > 0 {
+		logger.Warningf("RPC server stopped, %d pending calls aborted", report.Aborted)
+	}
+	return report
+}
+
+// idle reports whether none of the sessions has calls in flight.
+func idle(sessions []*session) bool {
+	for _, sess := range sessions {
+		if atomic.LoadInt64(&sess.inflight) > 0 {
+			return false
+		}
 	}
+	return true
 }
 
 // RPCService gives meta information about the server.
@@ -19,6 +19,7 @@ package rpc
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"io"
 	"io/ioutil"
 	"net"
@@ -51,6 +52,36 @@ func TestServerRegisterName(t *testing.T) {
 	}
 }
 
+func TestServerRegisterMethods(t *testing.T) {
+	server := NewServer()
+	defer server.Stop()
+	methods := Methods{
+		"add":  func(a, b int) int { return a + b },
+		"echo": func(ctx context.Context, s string) (string, error) { return s, nil },
+		"bad":  42,
+	}
+	if err := server.RegisterName("calc", methods); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(server.services.services["calc"].callbacks); n != 2 {
+		t.Fatalf("Expected 2 callbacks, got %d", n)
+	}
+
+	client := DialInProc(server)
+	defer client.Close()
+	var sum int
+	if err := client.Call(&sum, "calc_add", 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	var echo string
+	if err := client.Call(&echo, "calc_echo", "x"); err != nil {
+		t.Fatal(err)
+	}
+	if sum != 3 || echo != "x" {
+		t.Errorf("wrong results %d %q", sum, echo)
+	}
+}
+
 func TestServer(t *testing.T) {
 	files, err := ioutil.ReadDir("testdata")
 	if err != nil {
@@ -150,3 +181,155 @@ func TestServerShortLivedConn(t *testing.T) {
 		}
 	}
 }
+
+func TestServerSessions(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+
+	client, hs := httpTestClient(server, "ws", nil)
+	defer hs.Close()
+	defer client.Close()
+
+	sub, err := client.Subscribe(context.Background(), "nftest", make(chan int, 10), "someSubscription", 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	sessions := server.Sessions()
+	if len(sessions) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(sessions))
+	}
+	if sessions[0].Transport != TransportWS {
+		t.Errorf("wrong transport %q", sessions[0].Transport)
+	}
+	if sessions[0].Origin == "" {
+		t.Error("origin not recorded")
+	}
+	if sessions[0].Subscriptions != 1 {
+		t.Errorf("expected 1 subscription, got %d", sessions[0].Subscriptions)
+	}
+
+	if err := server.Disconnect("0xdead"); err != ErrSessionNotFound {
+		t.Errorf("expected %v, got %v", ErrSessionNotFound, err)
+	}
+	if err := server.Disconnect(sessions[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-sub.Err():
+	case <-time.After(2 * time.Second):
+		t.Fatal("subscription not closed after disconnect")
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for len(server.Sessions()) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("session not removed after disconnect")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestServerSessionTransport(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+
+	// Plain HTTP requests are not sessions.
+	client, hs := httpTestClient(server, "http", nil)
+	defer hs.Close()
+	defer client.Close()
+	callErr := make(chan error, 1)
+	go func() {
+		callErr <- client.Call(nil, "test_sleep", 200*time.Millisecond)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if sessions := server.Sessions(); len(sessions) != 0 {
+		t.Errorf("HTTP request registered as session %+v", sessions)
+	}
+	if err := <-callErr; err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go server.ServeListener(listener)
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	deadline := time.Now().Add(2 * time.Second)
+	for len(server.Sessions()) != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("TCP connection not registered")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if transport := server.Sessions()[0].Transport; transport != TransportTCP {
+		t.Errorf("wrong transport %q", transport)
+	}
+}
+
+func TestServerShutdown(t *testing.T) {
+	server := newTestServer()
+	client, hs := httpTestClient(server, "ws", nil)
+	defer hs.Close()
+	defer client.Close()
+
+	sub, err := client.Subscribe(context.Background(), "nftest", make(chan int, 10), "someSubscription", 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	callErr := make(chan error, 1)
+	go func() {
+		callErr <- client.Call(nil, "test_sleep", 200*time.Millisecond)
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	report := server.Shutdown(ctx)
+	if report.Sessions != 1 || report.Subscriptions != 1 || report.Aborted != 0 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if err := <-callErr; err != nil {
+		t.Errorf("pending call failed: %v", err)
+	}
+	select {
+	case err := <-sub.Err():
+		if err == nil || err.Error() != ErrServerShutdown.Error() {
+			t.Errorf("expected %v, got %v", ErrServerShutdown, err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("subscription not closed after shutdown")
+	}
+}
+
+func TestServerShutdownAbort(t *testing.T) {
+	server := newTestServer()
+	client, hs := httpTestClient(server, "http", nil)
+	defer hs.Close()
+	defer client.Close()
+
+	callErr := make(chan error, 1)
+	go func() {
+		callErr <- client.Call(nil, "test_sleep", 2*time.Second)
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if report := server.Shutdown(ctx); report.Aborted != 1 {
+		t.Errorf("expected 1 aborted call, got %+v", report)
+	}
+
+	var resp Result
+	err := client.Call(&resp, "test_echo", "x", 1, nil)
+	if err == nil || err.Error() != ErrServerShutdown.Error() {
+		t.Errorf("expected %v, got %v", ErrServerShutdown, err)
+	}
+	<-callErr
+}
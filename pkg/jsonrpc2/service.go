@@ -62,7 +62,12 @@ func (r *serviceRegistry) registerName(name string, rcvr interface{}) error {
 	if name == "" {
 		return fmt.Errorf("no service name for type %s", rcvrVal.Type().String())
 	}
-	callbacks := suitableCallbacks(rcvrVal)
+	var callbacks map[string]*callback
+	if methods, ok := rcvr.(Methods); ok {
+		callbacks = methods.callbacks()
+	} else {
+		callbacks = suitableCallbacks(rcvrVal)
+	}
 	if len(callbacks) == 0 {
 		return fmt.Errorf("service %T doesn't have any suitable methods/subscriptions to expose", rcvr)
 	}
@@ -109,6 +114,28 @@ func (r *serviceRegistry) subscription(service, name string) *callback {
 	return r.services[service].subscriptions[name]
 }
 
+// Methods is a service made of plain functions keyed by method name, for services which
+// are assembled at runtime. The functions must satisfy the criteria for RPC methods,
+// subscriptions are not supported.
+type Methods map[string]interface{}
+
+// callbacks returns the suitable functions of m.
+func (m Methods) callbacks() map[string]*callback {
+	callbacks := make(map[string]*callback)
+	for name, fn := range m {
+		fnVal := reflect.ValueOf(fn)
+		if fnVal.Kind() != reflect.Func {
+			continue
+		}
+		cb := newCallback(reflect.Value{}, fnVal)
+		if cb == nil || cb.isSubscribe {
+			continue // function invalid
+		}
+		callbacks[name] = cb
+	}
+	return callbacks
+}
+
 // suitableCallbacks iterates over the methods of the given type. It determines if a method
 // satisfies the criteria for a RPC callback or a subscription callback and adds it to the
 // collection of callbacks. See server documentation for a summary of these criteria.
@@ -0,0 +1,218 @@
+package rpc
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrSessionNotFound is returned by Disconnect when no session has the given ID.
+var ErrSessionNotFound = errors.New("session not found")
+
+// Transport names recorded in SessionInfo.
+const (
+	TransportHTTP   = "http"
+	TransportWS     = "ws"
+	TransportIPC    = "ipc"
+	TransportTCP    = "tcp"
+	TransportStdIO  = "stdio"
+	TransportInProc = "inproc"
+)
+
+// networkTransport returns the transport name of connections accepted on a listener of
+// the given network.
+func networkTransport(network string) string {
+	switch network {
+	case "unix", "unixpacket", "pipe":
+		return TransportIPC
+	case "tcp", "tcp4", "tcp6":
+		return TransportTCP
+	case "websocket":
+		return TransportWS
+	default:
+		return network
+	}
+}
+
+// codecTransport returns the transport name of a connection served through ServeCodec.
+// It is empty for codecs not created by NewCodec or NewJSONCodec.
+func codecTransport(codec ServerCodec) string {
+	c, ok := codec.(*jsonCodec)
+	if !ok {
+		return ""
+	}
+	conn := c.conn
+	if wrapped, ok := conn.(connWithRemoteAddr); ok {
+		conn = wrapped.Conn
+	}
+	switch conn := conn.(type) {
+	case stdioConn:
+		return TransportStdIO
+	case interface{ LocalAddr() net.Addr }:
+		return networkTransport(conn.LocalAddr().Network())
+	default:
+		return ""
+	}
+}
+
+// SessionInfo describes a connection served by a Server.
+type SessionInfo struct {
+	ID            ID        `json:"id"`
+	RemoteAddr    string    `json:"remoteAddr"`
+	Transport     string    `json:"transport"`
+	ConnectedAt   time.Time `json:"connectedAt"`
+	UserAgent     string    `json:"userAgent,omitempty"`
+	Origin        string    `json:"origin,omitempty"`
+	InflightCalls int64     `json:"inflightCalls"`
+	Subscriptions int64     `json:"subscriptions"`
+}
+
+// session tracks a single connection. The counters are maintained by the handler
+// serving the connection.
+type session struct {
+	info     SessionInfo
+	close    func()
+	inflight int64
+	subs     int64
+	draining int32
+
+	mu sync.Mutex
+	h  *handler
+}
+
+// setHandler records the handler serving the connection.
+func (s *session) setHandler(h *handler) {
+	if s != nil {
+		s.mu.Lock()
+		s.h = h
+		s.mu.Unlock()
+	}
+}
+
+// drain makes the handler reject new calls.
+func (s *session) drain() {
+	atomic.StoreInt32(&s.draining, 1)
+}
+
+func (s *session) isDraining() bool {
+	return s != nil && atomic.LoadInt32(&s.draining) == 1
+}
+
+// notifyShutdown sends a close notice to the subscribers of the connection and returns
+// the number of notified subscriptions.
+func (s *session) notifyShutdown() int {
+	s.mu.Lock()
+	h := s.h
+	s.mu.Unlock()
+	if h == nil {
+		return 0
+	}
+	return h.notifySubscriptionsClosed(ErrServerShutdown.Error())
+}
+
+func (s *session) addInflight(delta int64) {
+	if s != nil {
+		atomic.AddInt64(&s.inflight, delta)
+	}
+}
+
+func (s *session) addSubscriptions(delta int64) {
+	if s != nil {
+		atomic.AddInt64(&s.subs, delta)
+	}
+}
+
+func (s *session) snapshot() SessionInfo {
+	info := s.info
+	info.InflightCalls = atomic.LoadInt64(&s.inflight)
+	info.Subscriptions = atomic.LoadInt64(&s.subs)
+	return info
+}
+
+// sessionRegistry keeps track of all connections of a Server.
+type sessionRegistry struct {
+	mu       sync.Mutex
+	sessions map[ID]*session
+}
+
+func newSessionRegistry() *sessionRegistry {
+	return &sessionRegistry{sessions: make(map[ID]*session)}
+}
+
+func (r *sessionRegistry) add(s *session) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions[s.info.ID] = s
+}
+
+func (r *sessionRegistry) remove(s *session) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.sessions, s.info.ID)
+}
+
+func (r *sessionRegistry) get(id ID) *session {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sessions[id]
+}
+
+// all returns the registered sessions. The registry lock is not held while the caller
+// works with the result.
+func (r *sessionRegistry) all() []*session {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sessions := make([]*session, 0, len(r.sessions))
+	for _, s := range r.sessions {
+		sessions = append(sessions, s)
+	}
+	return sessions
+}
+
+// newSession creates a session for the given connection. The request, if any, is used
+// to record the user agent and origin of the peer.
+func (s *Server) newSession(transport, remoteAddr string, r *http.Request, close func()) *session {
+	sess := &session{
+		info: SessionInfo{
+			ID:          NewID(),
+			RemoteAddr:  remoteAddr,
+			Transport:   transport,
+			ConnectedAt: time.Now(),
+		},
+		close: close,
+	}
+	if r != nil {
+		sess.info.UserAgent = r.Header.Get("User-Agent")
+		sess.info.Origin = r.Header.Get("Origin")
+	}
+	return sess
+}
+
+// Sessions returns information about all connections currently served, ordered by
+// connect time.
+func (s *Server) Sessions() []SessionInfo {
+	sessions := s.sessions.all()
+	infos := make([]SessionInfo, 0, len(sessions))
+	for _, sess := range sessions {
+		infos = append(infos, sess.snapshot())
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ConnectedAt.Before(infos[j].ConnectedAt)
+	})
+	return infos
+}
+
+// Disconnect forcibly closes the connection with the given session ID. Pending requests
+// and subscriptions of the session are canceled.
+func (s *Server) Disconnect(id ID) error {
+	sess := s.sessions.get(id)
+	if sess == nil {
+		return ErrSessionNotFound
+	}
+	sess.close()
+	return nil
+}
@@ -99,6 +99,26 @@ func (s *testService) CallMeBackLater(ctx context.Context, method string, args [
 	return nil
 }
 
+type peerTestService struct{}
+
+var errNoReverseCalls = errors.New("no client in the context")
+
+func (s *peerTestService) Sign(ctx context.Context, data string) (string, error) {
+	client, ok := ClientFromContext(ctx)
+	if !ok {
+		return "", errNoReverseCalls
+	}
+	var sig string
+	err := client.CallContext(ctx, &sig, "agent_sign", data)
+	return sig, err
+}
+
+type agentTestService struct{}
+
+func (s *agentTestService) Sign(data string) string {
+	return "signed:" + data
+}
+
 func (s *testService) Subscription(ctx context.Context) (*Subscription, error) {
 	return nil, nil
 }
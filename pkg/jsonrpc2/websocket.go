@@ -59,8 +59,10 @@ func (s *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 	return websocket.Server{
 		Handshake: wsHandshakeValidator(allowedOrigins),
 		Handler: func(conn *websocket.Conn) {
+			// Clear the deadlines set by the HTTP server, the connection is long-lived.
+			conn.SetDeadline(time.Time{})
 			codec := newWebsocketCodec(conn)
-			s.ServeCodec(codec, OptionMethodInvocation|OptionSubscriptions)
+			s.serveCodec(codec, TransportWS, conn.Request())
 		},
 	}
 }
@@ -186,6 +188,28 @@ func DialWebsocket(ctx context.Context, endpoint, origin string) (*Client, error
 	})
 }
 
+// DialWebsocketUnix creates a new RPC client that connects to a websocket RPC server
+// listening on the unix socket at path.
+func DialWebsocketUnix(ctx context.Context, path, origin string) (*Client, error) {
+	config, err := wsGetConfig("ws://localhost/", origin)
+	if err != nil {
+		return nil, err
+	}
+
+	return newClient(ctx, func(ctx context.Context) (ServerCodec, error) {
+		conn, err := dialContext(ctx, "unix", path)
+		if err != nil {
+			return nil, err
+		}
+		ws, err := websocket.NewClient(config, conn)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return newWebsocketCodec(ws), nil
+	})
+}
+
 func wsDialContext(ctx context.Context, config *websocket.Config) (*websocket.Conn, error) {
 	var conn net.Conn
 	var err error
@@ -16,7 +16,11 @@
 
 package rpc
 
-import "testing"
+import (
+	"context"
+	"net/http"
+	"testing"
+)
 
 func TestWSGetConfigNoAuth(t *testing.T) {
 	config, err := wsGetConfig("ws://example.com:1234", "")
@@ -52,3 +56,74 @@ func TestWSGetConfigWithBasicAuth(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestCombinedEndpoint(t *testing.T) {
+	apis := []API{{Namespace: "test", Service: new(testService), Public: true}}
+	cfg := &EndpointConfig{Cors: []string{"*"}, Vhosts: []string{"*"}, Prefix: "/rpc/"}
+	listener, server, err := StartCombinedEndpoint("tcp://127.0.0.1:0", apis, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	defer server.Stop()
+	addr := listener.Addr().String()
+
+	for _, url := range []string{"http://" + addr + "/rpc", "ws://" + addr + "/rpc"} {
+		client, err := DialContext(context.Background(), url)
+		if err != nil {
+			t.Fatalf("%s: %v", url, err)
+		}
+		var result Result
+		if err := client.Call(&result, "test_echo", "hello", 10, &Args{"world"}); err != nil {
+			t.Fatalf("%s: %v", url, err)
+		}
+		if result.String != "hello" || result.Int != 10 || result.Args.S != "world" {
+			t.Errorf("%s: wrong result %v", url, result)
+		}
+		client.Close()
+	}
+
+	resp, err := http.Post("http://"+addr+"/other", contentType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status %d for path outside the prefix, want 404", resp.StatusCode)
+	}
+}
+
+// CORS preflight requests carry no credentials and must pass the authentication.
+func TestCombinedEndpointAuthPreflight(t *testing.T) {
+	apis := []API{{Namespace: "test", Service: new(testService), Public: true}}
+	cfg := &EndpointConfig{Cors: []string{"*"}, Vhosts: []string{"*"}, Auth: &AuthConfig{Tokens: []string{"secret"}}}
+	listener, server, err := StartCombinedEndpoint("tcp://127.0.0.1:0", apis, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	defer server.Stop()
+	url := "http://" + listener.Addr().String()
+
+	req, _ := http.NewRequest(http.MethodOptions, url, nil)
+	req.Header.Set("Origin", "http://example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	req.Header.Set("Access-Control-Request-Headers", "Authorization, Content-Type")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 || resp.Header.Get("Access-Control-Allow-Origin") == "" {
+		t.Errorf("preflight status %d, headers %v", resp.StatusCode, resp.Header)
+	}
+
+	resp, err = http.Post(url, contentType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status %d without credentials, want 401", resp.StatusCode)
+	}
+}
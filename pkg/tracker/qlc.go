@@ -0,0 +1,114 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/drip/beyond/pkg/db"
+	qlcchain "github.com/qlcchain/qlc-go-sdk"
+	"github.com/qlcchain/qlc-go-sdk/pkg/types"
+)
+
+// QLCChain looks transactions up on a QLC node, the hash of a transaction is the hash of
+// its block. A block is final once it is confirmed, hashes which aren't block hashes
+// fail. The gas used is the amount of a block of the gas token, QGAS.
+type QLCChain struct {
+	endpoint string
+
+	mu       sync.Mutex
+	client   *qlcchain.QLCClient
+	gasToken *types.Hash
+}
+
+// NewQLCChain creates a chain looking transactions up on the node at endpoint. The node
+// is dialed on the first lookup and redialed after failures.
+func NewQLCChain(endpoint string) *QLCChain {
+	return &QLCChain{endpoint: endpoint}
+}
+
+// Receipt looks the block of hash up, see QLCChain.
+func (c *QLCChain) Receipt(ctx context.Context, hash string) (*Receipt, error) {
+	h, err := types.NewHash(hash)
+	if err != nil {
+		return &Receipt{Status: db.TxFailed}, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client == nil {
+		client, err := qlcchain.NewQLCClient(c.endpoint)
+		if err != nil {
+			return nil, err
+		}
+		if client == nil {
+			return nil, errors.New("no qlc client")
+		}
+		c.client = client
+	}
+
+	type result struct {
+		receipt  *Receipt
+		gasToken *types.Hash
+		err      error
+	}
+	results := make(chan result, 1)
+	go func(client *qlcchain.QLCClient, gasToken *types.Hash) {
+		if gasToken == nil {
+			token, err := client.Ledger.GasToken()
+			if err != nil {
+				results <- result{err: err}
+				return
+			}
+			gasToken = token
+		}
+		r, err := receipt(client, h, gasToken)
+		results <- result{r, gasToken, err}
+	}(c.client, c.gasToken)
+
+	var r result
+	select {
+	case r = <-results:
+	case <-ctx.Done():
+		r.err = ctx.Err()
+	}
+	if r.err != nil {
+		if notFound(r.err) {
+			return nil, nil
+		}
+		c.client.Close()
+		c.client = nil
+		return nil, r.err
+	}
+	c.gasToken = r.gasToken
+	return r.receipt, nil
+}
+
+// receipt looks the block h up.
+func receipt(client *qlcchain.QLCClient, h types.Hash, gasToken *types.Hash) (*Receipt, error) {
+	confirmed, err := client.Ledger.BlockConfirmedStatus(h)
+	if err != nil || !confirmed {
+		return nil, err
+	}
+	block, err := client.Ledger.BlockInfo(h)
+	if err != nil {
+		return nil, err
+	}
+	r := &Receipt{Status: db.TxConfirmed}
+	if block.StateBlock != nil {
+		if block.Timestamp > 0 {
+			r.ConfirmedAt = time.Unix(block.Timestamp, 0)
+		}
+		if gasToken != nil && block.Token == *gasToken && block.Amount.Int != nil && block.Amount.IsInt64() {
+			r.GasUsed = block.Amount.Int64()
+		}
+	}
+	return r, nil
+}
+
+// notFound reports whether the node doesn't know a block.
+func notFound(err error) bool {
+	return strings.Contains(err.Error(), "not found")
+}
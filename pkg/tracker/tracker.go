@@ -0,0 +1,234 @@
+// Package tracker follows the transactions of the recorded swaps on the chain until they
+// confirm, fail or are dropped.
+package tracker
+
+import (
+	"context"
+	"time"
+
+	"github.com/drip/beyond/pkg/db"
+	"github.com/drip/beyond/pkg/log"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultInterval   = 15 * time.Second
+	DefaultMaxBackoff = 10 * time.Minute
+	DefaultDropAfter  = time.Hour
+	DefaultTimeout    = 10 * time.Second
+)
+
+// Receipt is the final state of a transaction.
+type Receipt struct {
+	// db.TxConfirmed or db.TxFailed
+	Status      string
+	ConfirmedAt time.Time
+	GasUsed     int64
+}
+
+// Chain looks transactions up. Receipt returns nil without an error while a transaction
+// isn't final, including transactions the chain doesn't know (yet).
+type Chain interface {
+	Receipt(ctx context.Context, hash string) (*Receipt, error)
+}
+
+// Options control how often transactions are checked.
+type Options struct {
+	// time between the checks of a pending transaction, doubled after each check up to
+	// MaxBackoff
+	Interval   time.Duration
+	MaxBackoff time.Duration
+	// transactions still pending DropAfter after the swap are dropped
+	DropAfter time.Duration
+	// limit of a lookup
+	Timeout time.Duration
+}
+
+func (o *Options) withDefaults() Options {
+	opts := Options{}
+	if o != nil {
+		opts = *o
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = DefaultMaxBackoff
+	}
+	if opts.MaxBackoff < opts.Interval {
+		opts.MaxBackoff = opts.Interval
+	}
+	if opts.DropAfter <= 0 {
+		opts.DropAfter = DefaultDropAfter
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	return opts
+}
+
+// Tracker checks the pending transactions of the actions database periodically and
+// records their final status through the writer. Actions are passed to publish once
+// their transaction is final.
+type Tracker struct {
+	writer  *db.Writer
+	chain   Chain
+	opts    Options
+	publish func(action *db.InchAction)
+	logger  *zap.SugaredLogger
+
+	// backoff of the pending transactions by action id, used by the tracking goroutine
+	retries map[uint]*retry
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+type retry struct {
+	attempts uint
+	next     time.Time
+}
+
+// New creates a tracker, nil options select the defaults. publish may be nil.
+func New(writer *db.Writer, chain Chain, opts *Options, publish func(action *db.InchAction)) *Tracker {
+	return &Tracker{
+		writer:  writer,
+		chain:   chain,
+		opts:    opts.withDefaults(),
+		publish: publish,
+		logger:  log.NewLogger("tracker"),
+		retries: make(map[uint]*retry),
+		done:    make(chan struct{}),
+	}
+}
+
+// Start checks the pending transactions now and every interval.
+func (t *Tracker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+	go func() {
+		defer close(t.done)
+		ticker := time.NewTicker(t.opts.Interval)
+		defer ticker.Stop()
+		for {
+			t.check(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops the tracker, running lookups are canceled.
+func (t *Tracker) Stop() {
+	if t.cancel == nil {
+		return
+	}
+	t.cancel()
+	<-t.done
+}
+
+// check looks up the pending transactions which are due.
+func (t *Tracker) check(ctx context.Context) {
+	actions, err := db.PendingTransactions(t.writer.DB().WithContext(ctx))
+	if err != nil {
+		if ctx.Err() == nil {
+			t.logger.Error(err)
+		}
+		return
+	}
+	pending := make(map[uint]bool, len(actions))
+	for _, action := range actions {
+		if ctx.Err() != nil {
+			return
+		}
+		pending[action.ID] = true
+		r, ok := t.retries[action.ID]
+		if !ok {
+			r = &retry{}
+			t.retries[action.ID] = r
+		}
+		now := time.Now()
+		if now.Before(r.next) {
+			continue
+		}
+
+		receipt, err := t.lookup(ctx, action.TxHash)
+		if err != nil {
+			if ctx.Err() == nil {
+				t.logger.Warnf("transaction %s of action %d: %s", action.TxHash, action.ID, err)
+			}
+			t.backoff(r, now)
+			continue
+		}
+		if receipt == nil {
+			if now.Sub(action.CreatedAt) < t.opts.DropAfter {
+				t.backoff(r, now)
+				continue
+			}
+			receipt = &Receipt{Status: db.TxDropped}
+		}
+		if err := t.finalize(ctx, action, receipt); err != nil {
+			if ctx.Err() == nil {
+				t.logger.Errorf("action %d: %s", action.ID, err)
+			}
+			t.backoff(r, now)
+			continue
+		}
+		delete(t.retries, action.ID)
+	}
+	// forget the transactions finalized elsewhere
+	for id := range t.retries {
+		if !pending[id] {
+			delete(t.retries, id)
+		}
+	}
+}
+
+func (t *Tracker) lookup(ctx context.Context, hash string) (*Receipt, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.opts.Timeout)
+	defer cancel()
+	return t.chain.Receipt(ctx, hash)
+}
+
+// backoff doubles the time until the next check of a transaction.
+func (t *Tracker) backoff(r *retry, now time.Time) {
+	delay := t.opts.MaxBackoff
+	if r.attempts < 32 {
+		if d := t.opts.Interval << r.attempts; d > 0 && d < delay {
+			delay = d
+		}
+	}
+	r.attempts++
+	r.next = now.Add(delay)
+}
+
+// finalize records the final status of the transaction of action and publishes it.
+func (t *Tracker) finalize(ctx context.Context, action *db.InchAction, receipt *Receipt) error {
+	var confirmedAt *time.Time
+	if receipt.Status == db.TxConfirmed {
+		at := receipt.ConfirmedAt
+		if at.IsZero() {
+			at = time.Now()
+		}
+		confirmedAt = &at
+	}
+	var updated bool
+	err := t.writer.Write(ctx, func(tx *gorm.DB) error {
+		var err error
+		updated, err = db.SetTxStatus(tx, action.ID, receipt.Status, confirmedAt, receipt.GasUsed)
+		return err
+	})
+	if err != nil || !updated {
+		return err
+	}
+	action.Status, action.ConfirmedAt, action.GasUsed = receipt.Status, confirmedAt, receipt.GasUsed
+	t.logger.Infof("transaction %s of action %d %s", action.TxHash, action.ID, action.Status)
+	if t.publish != nil {
+		t.publish(action)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/drip/beyond/pkg/db"
+	"gorm.io/gorm"
+)
+
+// fakeChain answers with the receipts of its transactions after some lookups.
+type fakeChain struct {
+	mu       sync.Mutex
+	lookups  map[string]int
+	receipts map[string]*Receipt
+	// lookups before a receipt is returned
+	after map[string]int
+	// lookups failing first
+	errs map[string]int
+}
+
+func (c *fakeChain) Receipt(_ context.Context, hash string) (*Receipt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lookups[hash]++
+	if c.lookups[hash] <= c.errs[hash] {
+		return nil, errors.New("node unavailable")
+	}
+	if c.lookups[hash] <= c.after[hash] {
+		return nil, nil
+	}
+	return c.receipts[hash], nil
+}
+
+func (c *fakeChain) count(hash string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lookups[hash]
+}
+
+func TestTracker(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tracker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	database, err := db.NewDB(filepath.Join(dir, "actions.db"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if sqlDB, err := database.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}()
+	writer := db.NewWriter(database, 0, 0)
+	writer.Start()
+	defer writer.Stop()
+
+	old := time.Now().Add(-time.Hour)
+	actions := map[string]*db.InchAction{
+		"confirmed": {Pair: "QLC/QGAS", TxHash: "confirmed"},
+		"retried":   {Pair: "QLC/QGAS", TxHash: "retried"},
+		"failed":    {Pair: "QLC/QGAS", TxHash: "failed"},
+		"dropped":   {Pair: "QLC/QGAS", TxHash: "dropped", Model: gorm.Model{CreatedAt: old}},
+		"none":      {Pair: "QLC/QGAS"},
+	}
+	for _, a := range actions {
+		if err := db.InsertSwapResult(database, a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	confirmedAt := time.Unix(1600000000, 0)
+	chain := &fakeChain{
+		lookups: make(map[string]int),
+		receipts: map[string]*Receipt{
+			"confirmed": {Status: db.TxConfirmed, ConfirmedAt: confirmedAt, GasUsed: 21},
+			"retried":   {Status: db.TxConfirmed},
+			"failed":    {Status: db.TxFailed},
+		},
+		after: map[string]int{"confirmed": 2, "dropped": 1000},
+		errs:  map[string]int{"retried": 3},
+	}
+
+	published := make(chan *db.InchAction, 10)
+	tracker := New(writer, chain, &Options{Interval: 5 * time.Millisecond, MaxBackoff: 20 * time.Millisecond, DropAfter: 30 * time.Minute},
+		func(action *db.InchAction) {
+			published <- action
+		})
+	tracker.Start()
+	final := make(map[string]*db.InchAction)
+	for len(final) < 4 {
+		select {
+		case a := <-published:
+			final[a.TxHash] = a
+		case <-time.After(5 * time.Second):
+			t.Fatalf("finalized %d transactions", len(final))
+		}
+	}
+	tracker.Stop()
+
+	for hash, status := range map[string]string{"confirmed": db.TxConfirmed, "retried": db.TxConfirmed, "failed": db.TxFailed, "dropped": db.TxDropped} {
+		a, err := db.GetInchActionByID(database, actions[hash].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.Status != status || final[hash].Status != status {
+			t.Errorf("%s: status %s, published %s", hash, a.Status, final[hash].Status)
+		}
+	}
+	a, err := db.GetInchActionByID(database, actions["confirmed"].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.ConfirmedAt == nil || !a.ConfirmedAt.Equal(confirmedAt) || a.GasUsed != 21 {
+		t.Errorf("confirmed %+v", a)
+	}
+	if a, err := db.GetInchActionByID(database, actions["retried"].ID); err != nil || a.ConfirmedAt == nil {
+		t.Errorf("confirmation time of retried not set: %v", err)
+	}
+	if n := chain.count("retried"); n != 4 {
+		t.Errorf("retried %d lookups", n)
+	}
+	if n := chain.count("dropped"); n != 1 {
+		t.Errorf("dropped after %d lookups", n)
+	}
+	if n := chain.count(""); n != 0 {
+		t.Error("looked up an action without a transaction")
+	}
+	if a, err := db.GetInchActionByID(database, actions["none"].ID); err != nil {
+		t.Fatal(err)
+	} else if a.Status != "" {
+		t.Errorf("status without a transaction %q", a.Status)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tracker := New(nil, nil, &Options{Interval: time.Second, MaxBackoff: 5 * time.Second}, nil)
+	now := time.Now()
+	r := &retry{}
+	for _, want := range []time.Duration{1, 2, 4, 5, 5} {
+		tracker.backoff(r, now)
+		if d := r.next.Sub(now); d != want*time.Second {
+			t.Fatalf("attempt %d: backoff %s, expected %ds", r.attempts, d, want)
+		}
+	}
+	r.attempts = 100
+	tracker.backoff(r, now)
+	if d := r.next.Sub(now); d != 5*time.Second {
+		t.Fatalf("backoff %s after many attempts", d)
+	}
+}
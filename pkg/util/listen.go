@@ -0,0 +1,149 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// SocketOptions sets the file mode and ownership of unix domain socket listeners.
+// Owner and Group may be names or numeric ids, empty values keep the process defaults.
+type SocketOptions struct {
+	Mode  os.FileMode
+	Owner string
+	Group string
+}
+
+// DefaultSocketOptions only gives the owner access to the socket.
+var DefaultSocketOptions = &SocketOptions{Mode: 0600}
+
+// IsUnixNetwork reports whether network is served through a unix domain socket.
+func IsUnixNetwork(network string) bool {
+	return network == "unix" || network == "unixpacket"
+}
+
+// IsAbstractSocket reports whether address names a socket in the linux abstract
+// namespace, which has no file system representation.
+func IsAbstractSocket(address string) bool {
+	return strings.HasPrefix(address, "@")
+}
+
+// SocketPath extracts the socket path from endpoints of the form unix:///abs/path,
+// unix://relative/path, unix:path and unix:@abstract. Any scheme is accepted, so
+// http+unix:///run/gbeyond.sock yields the same path.
+func SocketPath(endpoint string) (string, error) {
+	i := strings.Index(endpoint, ":")
+	if i < 0 {
+		return "", fmt.Errorf("missing scheme in %s", endpoint)
+	}
+	address := strings.TrimPrefix(endpoint[i+1:], "//")
+	if address == "" {
+		return "", fmt.Errorf("missing socket path in %s", endpoint)
+	}
+	if IsAbstractSocket(address) && runtime.GOOS != "linux" && runtime.GOOS != "android" {
+		return "", fmt.Errorf("abstract unix sockets are not supported on %s", runtime.GOOS)
+	}
+	return address, nil
+}
+
+// Listen announces on the given endpoint, e.g. tcp://0.0.0.0:29705 or
+// unix:///run/gbeyond.sock. For unix sockets, stale socket files are removed and the
+// file mode and ownership of opts are applied, the socket is created with the umask
+// restricted to the mode where supported. If opts is nil, DefaultSocketOptions is used.
+func Listen(endpoint string, opts *SocketOptions) (net.Listener, error) {
+	network, address, err := Scheme(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if !IsUnixNetwork(network) {
+		return net.Listen(network, address)
+	}
+	if IsAbstractSocket(address) {
+		return net.Listen(network, address)
+	}
+
+	if opts == nil {
+		opts = DefaultSocketOptions
+	}
+	if err := os.MkdirAll(filepath.Dir(address), 0751); err != nil {
+		return nil, err
+	}
+	if fi, err := os.Lstat(address); err == nil {
+		if fi.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", address)
+		}
+		if err := os.Remove(address); err != nil {
+			return nil, err
+		}
+	}
+	l, err := listenUnix(network, address, opts.Mode)
+	if err != nil {
+		return nil, err
+	}
+	if err := applySocketOptions(address, opts); err != nil {
+		l.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+func applySocketOptions(path string, opts *SocketOptions) error {
+	if opts.Mode != 0 {
+		if err := os.Chmod(path, opts.Mode); err != nil {
+			return err
+		}
+	}
+	if opts.Owner == "" && opts.Group == "" {
+		return nil
+	}
+	uid, gid := -1, -1
+	if opts.Owner != "" {
+		id, err := lookupID(opts.Owner, func(name string) (string, error) {
+			u, err := user.Lookup(name)
+			if err != nil {
+				return "", err
+			}
+			return u.Uid, nil
+		})
+		if err != nil {
+			return fmt.Errorf("socket owner %s: %s", opts.Owner, err)
+		}
+		uid = id
+	}
+	if opts.Group != "" {
+		id, err := lookupID(opts.Group, func(name string) (string, error) {
+			g, err := user.LookupGroup(name)
+			if err != nil {
+				return "", err
+			}
+			return g.Gid, nil
+		})
+		if err != nil {
+			return fmt.Errorf("socket group %s: %s", opts.Group, err)
+		}
+		gid = id
+	}
+	return os.Lchown(path, uid, gid)
+}
+
+// lookupID resolves a numeric id or a name through lookup.
+func lookupID(name string, lookup func(string) (string, error)) (int, error) {
+	if id, err := strconv.Atoi(name); err == nil {
+		return id, nil
+	}
+	s, err := lookup(name)
+	if err != nil {
+		return -1, err
+	}
+	id, err := strconv.Atoi(s)
+	if err != nil {
+		return -1, errors.New("ids are not numeric on this platform")
+	}
+	return id, nil
+}
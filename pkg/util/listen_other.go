@@ -0,0 +1,13 @@
+// +build !aix,!darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris
+
+package util
+
+import (
+	"net"
+	"os"
+)
+
+// listenUnix creates the socket, there is no umask to restrict it on this platform.
+func listenUnix(network, address string, _ os.FileMode) (net.Listener, error) {
+	return net.Listen(network, address)
+}
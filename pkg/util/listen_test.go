@@ -0,0 +1,78 @@
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestScheme(t *testing.T) {
+	cases := []struct {
+		endpoint, network, address string
+	}{
+		{"tcp://0.0.0.0:29705", "tcp", "0.0.0.0:29705"},
+		{"unix:///run/gbeyond.sock", "unix", "/run/gbeyond.sock"},
+		{"unix://gbeyond.sock", "unix", "gbeyond.sock"},
+		{"unix:gbeyond.sock", "unix", "gbeyond.sock"},
+	}
+	if runtime.GOOS == "linux" {
+		cases = append(cases,
+			struct{ endpoint, network, address string }{"unix:@gbeyond", "unix", "@gbeyond"},
+			struct{ endpoint, network, address string }{"unix://@gbeyond", "unix", "@gbeyond"},
+		)
+	}
+	for _, c := range cases {
+		network, address, err := Scheme(c.endpoint)
+		if err != nil {
+			t.Fatalf("%s: %s", c.endpoint, err)
+		}
+		if network != c.network || address != c.address {
+			t.Errorf("%s: got %s %s, want %s %s", c.endpoint, network, address, c.network, c.address)
+		}
+	}
+	if _, _, err := Scheme("unix://"); err == nil {
+		t.Error("expected error for empty socket path")
+	}
+}
+
+func TestListenUnix(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix sockets not supported")
+	}
+	dir, err := ioutil.TempDir("", "gbeyond")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "rpc", "gbeyond.sock")
+
+	// a stale socket is replaced
+	for i := 0; i < 2; i++ {
+		l, err := Listen("unix://"+path, &SocketOptions{Mode: 0660})
+		if err != nil {
+			t.Fatal(err)
+		}
+		fi, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Mode().Perm() != 0660 {
+			t.Errorf("wrong mode %o", fi.Mode().Perm())
+		}
+		if i == 0 {
+			if ul, ok := l.(interface{ SetUnlinkOnClose(bool) }); ok {
+				ul.SetUnlinkOnClose(false)
+			}
+		}
+		l.Close()
+	}
+
+	if err := ioutil.WriteFile(path, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Listen("unix://"+path, nil); err == nil {
+		t.Error("expected error when a regular file is in the way")
+	}
+}
@@ -0,0 +1,26 @@
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package util
+
+import (
+	"net"
+	"os"
+	"sync"
+	"syscall"
+)
+
+// umaskMu serializes the listeners changing the process wide umask.
+var umaskMu sync.Mutex
+
+// listenUnix creates the socket with the umask restricted to mode, so it is never
+// accessible beyond mode, not even until it is chmod'ed.
+func listenUnix(network, address string, mode os.FileMode) (net.Listener, error) {
+	if mode == 0 {
+		return net.Listen(network, address)
+	}
+	umaskMu.Lock()
+	defer umaskMu.Unlock()
+	old := syscall.Umask(int(^mode & os.ModePerm))
+	defer syscall.Umask(old)
+	return net.Listen(network, address)
+}
@@ -67,10 +67,16 @@ func String(length int) string {
 	return StringWithCharset(length, charset)
 }
 
+// Scheme splits endpoint into network and address. For unix sockets the address is the
+// socket path, see Listen for the accepted forms.
 func Scheme(endpoint string) (string, string, error) {
 	u, err := url.Parse(endpoint)
 	if err != nil {
 		return "", "", err
 	}
+	if IsUnixNetwork(u.Scheme) {
+		address, err := SocketPath(endpoint)
+		return u.Scheme, address, err
+	}
 	return u.Scheme, u.Host, nil
 }
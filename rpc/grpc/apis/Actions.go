@@ -0,0 +1,280 @@
+package apis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/drip/beyond/pkg/db"
+	"github.com/drip/beyond/pkg/log"
+	pb "github.com/drip/beyond/rpc/grpc/proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type ActionsApi struct {
+	db     *gorm.DB
+	events *EventApi
+	logger *zap.SugaredLogger
+
+	tokensMu sync.Mutex
+	tokens   map[string]uint8
+}
+
+// NewActionsApi serves the actions stored in database. New actions and the quotes they
+// were executed at are published to events by PublishAction.
+func NewActionsApi(database *gorm.DB, events *EventApi) *ActionsApi {
+	return &ActionsApi{
+		db:     database,
+		events: events,
+		logger: log.NewLogger("api/actions"),
+		tokens: make(map[string]uint8),
+	}
+}
+
+// PublishAction sends a committed action and the quote it was executed at to the events.
+// It looks up the decimals of the tokens in the database, so it must not be called
+// inside a transaction.
+func (a *ActionsApi) PublishAction(action *db.InchAction) {
+	if a.events == nil {
+		return
+	}
+	pbAction, err := a.toAction(action)
+	if err != nil {
+		a.logger.Error(err)
+		return
+	}
+	a.events.PublishAction(pbAction)
+	a.events.PublishQuote(&pb.Quote{
+		Pair:   pbAction.Pair,
+		Input:  pbAction.Input,
+		Output: pbAction.Output,
+		Price:  pbAction.Price,
+		Gas:    pbAction.Gas,
+	})
+}
+
+// PublishTransaction sends an action whose transaction is final to the events.
+func (a *ActionsApi) PublishTransaction(action *db.InchAction) {
+	if a.events == nil {
+		return
+	}
+	if pbAction, err := a.toAction(action); err != nil {
+		a.logger.Error(err)
+	} else {
+		a.events.PublishTransaction(pbAction)
+	}
+}
+
+func (a *ActionsApi) ListActions(ctx context.Context, req *pb.ListActionsRequest) (*pb.ActionPage, error) {
+	if req.Page < 0 || req.PageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page and page size must not be negative")
+	}
+	q, err := actionQuery(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	database := a.db.WithContext(ctx)
+	total, err := db.CountInchActionQuery(database, q)
+	if err != nil {
+		return nil, a.internal(err)
+	}
+	actions, next, err := db.QueryInchAction(database, q)
+	if err == db.ErrInvalidCursor {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return nil, a.internal(err)
+	}
+	page, pageSize := db.PageParams(q.Page, q.PageSize)
+	if q.Cursor != "" {
+		// the position of a cursor is not known
+		page = 0
+	}
+	result := &pb.ActionPage{
+		Actions:    make([]*pb.Action, 0, len(actions)),
+		Total:      total,
+		Page:       int32(page),
+		PageSize:   int32(pageSize),
+		TotalPages: int32(math.Ceil(float64(total) / float64(pageSize))),
+		NextCursor: next,
+	}
+	for _, action := range actions {
+		pbAction, err := a.toAction(action)
+		if err != nil {
+			return nil, a.internal(err)
+		}
+		result.Actions = append(result.Actions, pbAction)
+	}
+	return result, nil
+}
+
+// actionQuery converts the filters and order of req.
+func actionQuery(req *pb.ListActionsRequest) (*db.ActionQuery, error) {
+	q := &db.ActionQuery{
+		Pair:      req.Pair,
+		Ascending: req.Ascending,
+		Page:      int(req.Page),
+		PageSize:  int(req.PageSize),
+		Cursor:    req.Cursor,
+		Status:    req.Status,
+	}
+	if q.Status != "" {
+		if err := db.ValidTxStatus(q.Status); err != nil {
+			return nil, err
+		}
+	}
+	if req.IncludeSake != nil {
+		q.IncludeSake = &req.IncludeSake.Value
+	}
+	if req.HasTxHash != nil {
+		q.HasTxHash = &req.HasTxHash.Value
+	}
+	var err error
+	if q.MinProfit, err = parseProfit(req.MinProfit); err != nil {
+		return nil, err
+	}
+	if q.MaxProfit, err = parseProfit(req.MaxProfit); err != nil {
+		return nil, err
+	}
+	if q.Since, q.Until, err = timeRange(req.Since, req.Until); err != nil {
+		return nil, err
+	}
+	switch req.Sort {
+	case pb.ActionSort_TIME:
+		q.SortBy = db.SortByTime
+	case pb.ActionSort_PROFIT:
+		q.SortBy = db.SortByProfit
+	case pb.ActionSort_GAS:
+		q.SortBy = db.SortByGas
+	default:
+		return nil, fmt.Errorf("unknown sort order %d", req.Sort)
+	}
+	return q, nil
+}
+
+// parseProfit parses a profit bound, an empty one is unset.
+func parseProfit(s string) (*db.Decimal, error) {
+	if s == "" {
+		return nil, nil
+	}
+	d, err := db.ParseDecimal(s)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// timeRange converts a range of unix milliseconds, zero values leave it open.
+func timeRange(since, until int64) (time.Time, time.Time, error) {
+	var from, to time.Time
+	if since < 0 || until < 0 || (until > 0 && until <= since) {
+		return from, to, errors.New("invalid time range")
+	}
+	if since > 0 {
+		from = time.Unix(0, since*int64(time.Millisecond))
+	}
+	if until > 0 {
+		to = time.Unix(0, until*int64(time.Millisecond))
+	}
+	return from, to, nil
+}
+
+func (a *ActionsApi) GetAction(ctx context.Context, req *pb.ActionID) (*pb.Action, error) {
+	if req.Id == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid action id %d", req.Id)
+	}
+	action, err := db.GetInchActionByID(a.db.WithContext(ctx), uint(req.Id))
+	if err != nil {
+		return nil, a.lookupError(err, "action %d not found", req.Id)
+	}
+	pbAction, err := a.toAction(action)
+	if err != nil {
+		return nil, a.internal(err)
+	}
+	return pbAction, nil
+}
+
+func (a *ActionsApi) GetActionByTxHash(ctx context.Context, req *pb.TxHash) (*pb.Action, error) {
+	if req.TxHash == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing tx hash")
+	}
+	action, err := db.GetInchActionByTxHash(a.db.WithContext(ctx), req.TxHash)
+	if err != nil {
+		return nil, a.lookupError(err, "no action of tx %s", req.TxHash)
+	}
+	pbAction, err := a.toAction(action)
+	if err != nil {
+		return nil, a.internal(err)
+	}
+	return pbAction, nil
+}
+
+// toAction converts action with the decimals of its tokens. Token metadata rarely
+// changes, it is cached.
+func (a *ActionsApi) toAction(action *db.InchAction) (*pb.Action, error) {
+	in, out := db.PairTokens(action.Pair)
+	inDecimals, err := a.decimals(in)
+	if err != nil {
+		return nil, err
+	}
+	outDecimals, err := a.decimals(out)
+	if err != nil {
+		return nil, err
+	}
+	return toAction(action, inDecimals, outDecimals), nil
+}
+
+func (a *ActionsApi) decimals(symbol string) (uint8, error) {
+	a.tokensMu.Lock()
+	defer a.tokensMu.Unlock()
+	if d, ok := a.tokens[symbol]; ok {
+		return d, nil
+	}
+	d, err := db.TokenDecimals(a.db, symbol)
+	if err != nil {
+		return 0, err
+	}
+	a.tokens[symbol] = d
+	return d, nil
+}
+
+func (a *ActionsApi) lookupError(err error, format string, args ...interface{}) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return status.Errorf(codes.NotFound, format, args...)
+	}
+	return a.internal(err)
+}
+
+// internal logs database errors, clients only learn that the query failed.
+func (a *ActionsApi) internal(err error) error {
+	a.logger.Error(err)
+	return status.Error(codes.Internal, "database query failed")
+}
+
+func toAction(action *db.InchAction, inDecimals, outDecimals uint8) *pb.Action {
+	a := &pb.Action{
+		Id:          uint64(action.ID),
+		Pair:        action.Pair,
+		Input:       action.InPut.Decimal(inDecimals).String(),
+		Output:      action.OutPut.Decimal(outDecimals).String(),
+		Gas:         action.Gas,
+		Price:       action.Price.String(),
+		Profit:      action.Profit.String(),
+		TxHash:      action.TxHash,
+		IncludeSake: action.IncludeSake,
+		CreatedAt:   action.CreatedAt.UnixNano() / 1e6,
+		InputUnits:  action.InPut.String(),
+		OutputUnits: action.OutPut.String(),
+		Status:      action.Status,
+		GasUsed:     action.GasUsed,
+	}
+	if action.ConfirmedAt != nil {
+		a.ConfirmedAt = action.ConfirmedAt.UnixNano() / 1e6
+	}
+	return a
+}
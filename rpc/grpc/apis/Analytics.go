@@ -0,0 +1,109 @@
+package apis
+
+import (
+	"context"
+
+	"github.com/drip/beyond/pkg/db"
+	"github.com/drip/beyond/pkg/log"
+	pb "github.com/drip/beyond/rpc/grpc/proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type AnalyticsApi struct {
+	db     *gorm.DB
+	logger *zap.SugaredLogger
+}
+
+// NewAnalyticsApi aggregates the actions stored in database.
+func NewAnalyticsApi(database *gorm.DB) *AnalyticsApi {
+	return &AnalyticsApi{
+		db:     database,
+		logger: log.NewLogger("api/analytics"),
+	}
+}
+
+func (a *AnalyticsApi) PairStats(ctx context.Context, req *pb.StatsRequest) (*pb.PairStatsResponse, error) {
+	q, err := statsQuery(req.Pair, req.Since, req.Until)
+	if err != nil {
+		return nil, err
+	}
+	database := a.db.WithContext(ctx)
+	pairs, err := db.ActionStatsByPair(database, q)
+	if err != nil {
+		return nil, a.internal(err)
+	}
+	total, err := db.ActionStats(database, q)
+	if err != nil {
+		return nil, a.internal(err)
+	}
+	result := &pb.PairStatsResponse{
+		Pairs: make([]*pb.PairStats, 0, len(pairs)),
+		Total: toTradeStats(total),
+	}
+	for _, p := range pairs {
+		result.Pairs = append(result.Pairs, &pb.PairStats{Pair: p.Pair, Stats: toTradeStats(&p.TradeStats)})
+	}
+	return result, nil
+}
+
+func (a *AnalyticsApi) ProfitSeries(ctx context.Context, req *pb.PnLRequest) (*pb.PnLResponse, error) {
+	q, err := statsQuery(req.Pair, req.Since, req.Until)
+	if err != nil {
+		return nil, err
+	}
+	var bucket string
+	switch req.Bucket {
+	case pb.TimeBucket_HOUR:
+		bucket = db.BucketHour
+	case pb.TimeBucket_DAY:
+		bucket = db.BucketDay
+	case pb.TimeBucket_WEEK:
+		bucket = db.BucketWeek
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown time bucket %d", req.Bucket)
+	}
+	points, err := db.PnLSeries(a.db.WithContext(ctx), q, bucket)
+	if err != nil {
+		return nil, a.internal(err)
+	}
+	result := &pb.PnLResponse{Points: make([]*pb.PnLPoint, 0, len(points))}
+	for _, p := range points {
+		result.Points = append(result.Points, &pb.PnLPoint{
+			Time:             p.Time.UnixNano() / 1e6,
+			Stats:            toTradeStats(&p.TradeStats),
+			CumulativeProfit: p.CumulativeProfit.String(),
+		})
+	}
+	return result, nil
+}
+
+// internal logs database errors, clients only learn that the query failed.
+func (a *AnalyticsApi) internal(err error) error {
+	a.logger.Error(err)
+	return status.Error(codes.Internal, "database query failed")
+}
+
+func statsQuery(pair string, since, until int64) (*db.ActionQuery, error) {
+	q := &db.ActionQuery{Pair: pair}
+	var err error
+	if q.Since, q.Until, err = timeRange(since, until); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return q, nil
+}
+
+func toTradeStats(s *db.TradeStats) *pb.TradeStats {
+	return &pb.TradeStats{
+		Trades:        s.Trades,
+		TotalProfit:   s.TotalProfit.String(),
+		AverageProfit: s.AverageProfit.String(),
+		MedianProfit:  s.MedianProfit.String(),
+		Gas:           s.Gas,
+		WinRate:       s.WinRate(),
+		BestProfit:    s.BestProfit.String(),
+		WorstProfit:   s.WorstProfit.String(),
+	}
+}
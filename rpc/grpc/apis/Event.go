@@ -0,0 +1,192 @@
+package apis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/drip/beyond/config"
+	"github.com/drip/beyond/pkg/event"
+	"github.com/drip/beyond/pkg/log"
+	pb "github.com/drip/beyond/rpc/grpc/proto"
+	qlcchain "github.com/qlcchain/qlc-go-sdk"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const chainStatusInterval = 10 * time.Second
+
+type EventApi struct {
+	cfg     *config.Config
+	client  *qlcchain.QLCClient
+	actions *event.Feed
+	quotes  *event.Feed
+	chain   *event.Feed
+	txs     *event.Feed
+	cancel  context.CancelFunc
+	logger  *zap.SugaredLogger
+}
+
+// NewEventApi creates the event streams, the chain status is polled from the QLC node
+// at cfg.Endpoint once started.
+func NewEventApi(cfg *config.Config) (*EventApi, error) {
+	client, err := qlcchain.NewQLCClient(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("qlc client: %s", err)
+	}
+	if client == nil {
+		return nil, errors.New("qlc client: no client")
+	}
+	return &EventApi{
+		cfg:     cfg,
+		client:  client,
+		actions: event.NewFeed(event.DefaultHistory, event.DefaultBuffer),
+		quotes:  event.NewFeed(event.DefaultHistory, event.DefaultBuffer),
+		chain:   event.NewFeed(event.DefaultHistory, event.DefaultBuffer),
+		txs:     event.NewFeed(event.DefaultHistory, event.DefaultBuffer),
+		logger:  log.NewLogger("api/event"),
+	}, nil
+}
+
+// Start polls the chain status until Stop.
+func (e *EventApi) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	go e.pollChainStatus(ctx)
+}
+
+// PublishAction sends a trading action to the WatchActions subscribers.
+func (e *EventApi) PublishAction(action *pb.Action) uint64 {
+	return e.actions.Publish(action)
+}
+
+// PublishQuote sends a quote to the WatchQuotes subscribers, e.g. the quote a recorded
+// swap was executed at.
+func (e *EventApi) PublishQuote(quote *pb.Quote) uint64 {
+	return e.quotes.Publish(quote)
+}
+
+// PublishTransaction sends an action whose transaction is final to the WatchTransactions
+// subscribers.
+func (e *EventApi) PublishTransaction(action *pb.Action) uint64 {
+	return e.txs.Publish(action)
+}
+
+// Stop ends the chain status polling and all subscriptions.
+func (e *EventApi) Stop() {
+	if e.cancel != nil {
+		e.cancel()
+	}
+	e.actions.Close()
+	e.quotes.Close()
+	e.chain.Close()
+	e.txs.Close()
+}
+
+func (e *EventApi) WatchActions(req *pb.WatchRequest, srv pb.EventAPI_WatchActionsServer) error {
+	return e.watch(srv.Context(), e.actions, subscribeFrom(e.actions, req), func(ev *event.Event) error {
+		return srv.Send(&pb.ActionEvent{Sequence: ev.Seq, Time: millis(ev.Time), Action: ev.Data.(*pb.Action)})
+	})
+}
+
+func (e *EventApi) WatchQuotes(req *pb.WatchRequest, srv pb.EventAPI_WatchQuotesServer) error {
+	return e.watch(srv.Context(), e.quotes, subscribeFrom(e.quotes, req), func(ev *event.Event) error {
+		return srv.Send(&pb.QuoteEvent{Sequence: ev.Seq, Time: millis(ev.Time), Quote: ev.Data.(*pb.Quote)})
+	})
+}
+
+// WatchChainStatus sends the current status first unless resuming, then its changes.
+func (e *EventApi) WatchChainStatus(req *pb.WatchRequest, srv pb.EventAPI_WatchChainStatusServer) error {
+	subscribe := subscribeFrom(e.chain, req)
+	if req.GetFromSequence() == 0 {
+		subscribe = e.chain.SubscribeLatest
+	}
+	return e.watch(srv.Context(), e.chain, subscribe, func(ev *event.Event) error {
+		return srv.Send(&pb.ChainStatusEvent{Sequence: ev.Seq, Time: millis(ev.Time), Status: ev.Data.(*pb.ChainStatus)})
+	})
+}
+
+func (e *EventApi) WatchTransactions(req *pb.WatchRequest, srv pb.EventAPI_WatchTransactionsServer) error {
+	return e.watch(srv.Context(), e.txs, subscribeFrom(e.txs, req), func(ev *event.Event) error {
+		return srv.Send(&pb.ActionEvent{Sequence: ev.Seq, Time: millis(ev.Time), Action: ev.Data.(*pb.Action)})
+	})
+}
+
+type subscribeFunc func() ([]*event.Event, *event.Subscription, error)
+
+// subscribeFrom subscribes to feed after the sequence requested by req.
+func subscribeFrom(feed *event.Feed, req *pb.WatchRequest) subscribeFunc {
+	return func() ([]*event.Event, *event.Subscription, error) {
+		return feed.Subscribe(req.GetFromSequence())
+	}
+}
+
+// watch sends the events of feed until the client goes away. Clients which don't keep
+// up are disconnected, so a slow client never holds back the publishers; they resume
+// from the last sequence they received.
+func (e *EventApi) watch(ctx context.Context, feed *event.Feed, subscribe subscribeFunc, send func(*event.Event) error) error {
+	backlog, sub, err := subscribe()
+	switch err {
+	case nil:
+	case event.ErrSequenceExpired:
+		return status.Errorf(codes.OutOfRange, "%s, latest is %d", err, feed.Seq())
+	default:
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	defer sub.Unsubscribe()
+
+	for _, ev := range backlog {
+		if err := send(ev); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case ev := <-sub.Events():
+			if err := send(ev); err != nil {
+				return err
+			}
+		case err := <-sub.Err():
+			if err == event.ErrSlowConsumer {
+				e.logger.Warn("dropped slow event consumer")
+				return status.Error(codes.ResourceExhausted, err.Error())
+			}
+			return status.Error(codes.Unavailable, err.Error())
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// pollChainStatus publishes the chain status whenever it changes.
+func (e *EventApi) pollChainStatus(ctx context.Context) {
+	ticker := time.NewTicker(chainStatusInterval)
+	defer ticker.Stop()
+
+	var last *pb.ChainStatus
+	for {
+		current := &pb.ChainStatus{Online: true}
+		if counts, err := e.client.Ledger.BlocksCount(); err != nil {
+			current.Online = false
+			current.Error = err.Error()
+		} else {
+			current.Blocks = counts["count"]
+		}
+		if last == nil || last.Online != current.Online || last.Blocks != current.Blocks || last.Error != current.Error {
+			e.chain.Publish(current)
+			last = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func millis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
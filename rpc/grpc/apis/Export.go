@@ -0,0 +1,80 @@
+package apis
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/drip/beyond/pkg/export"
+	"github.com/drip/beyond/pkg/log"
+	pb "github.com/drip/beyond/rpc/grpc/proto"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/utilities"
+	"gorm.io/gorm"
+)
+
+// NewExportHandler serves the actions of database as a file download, e.g.
+// GET /export/actions?format=jsonl&pair=QLC/QGAS&since=1600000000000. The filters and the
+// order are the query parameters of ListActions, pages are ignored. The format is csv by
+// default, jsonl or parquet.
+func NewExportHandler(database *gorm.DB) http.Handler {
+	logger := log.NewLogger("api/export")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		values := r.URL.Query()
+		format := values.Get("format")
+		if format == "" {
+			format = export.FormatCSV
+		}
+		values.Del("format")
+		if err := export.ValidFormat(format); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req := &pb.ListActionsRequest{}
+		if err := runtime.PopulateQueryParameters(req, values, &utilities.DoubleArray{}); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		q, err := actionQuery(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		name := fmt.Sprintf("actions-%s.%s", time.Now().UTC().Format("20060102T150405Z"), format)
+		w.Header().Set("Content-Type", export.ContentType(format))
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+		cw := &countingWriter{w: w}
+		n, err := export.Actions(r.Context(), database, q, format, cw)
+		if err != nil {
+			if cw.n == 0 {
+				logger.Error(err)
+				w.Header().Del("Content-Disposition")
+				http.Error(w, "export failed", http.StatusInternalServerError)
+				return
+			}
+			// the status is sent, break the connection so the client doesn't take the
+			// truncated file for a complete one
+			logger.Errorf("export aborted after %d actions: %s", n, err)
+			panic(http.ErrAbortHandler)
+		}
+		logger.Debugf("exported %d actions as %s", n, format)
+	})
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w http.ResponseWriter
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,215 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	jsonrpc2 "github.com/drip/beyond/pkg/jsonrpc2"
+	protov1 "github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// params and results use the same field names as the RESTful gateway
+var (
+	bridgeUnmarshal = protojson.UnmarshalOptions{DiscardUnknown: true}
+	bridgeMarshal   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+)
+
+// bridgeAliases keeps the JSON-RPC methods of the hand-written services replaced by the
+// bridge, keyed by service and alias. An alias calls the gRPC method and returns the
+// value field of its result, like the replaced method did, e.g. ping_state returns
+// true instead of {"value": true}.
+var bridgeAliases = map[protoreflect.FullName]map[string]protoreflect.Name{
+	"proto.PingAPI": {"state": "Status"},
+}
+
+// bridgeExcluded keeps the services registered on the gRPC server that are not bridged.
+// The grpc.health.v1 service is meant for the probes of load balancers and
+// orchestrators, JSON-RPC clients get the component status from /healthz.
+var bridgeExcluded = map[string]bool{
+	"grpc.health.v1.Health": true,
+}
+
+// JSONRPCApis returns the services registered on the gRPC server as JSON-RPC APIs. A
+// service proto.PingAPI becomes the namespace ping with methods named like the gRPC
+// methods, e.g. ping_info. Params are passed as a single protojson object, omitted
+// params send an empty message. Streaming methods are not bridged.
+//
+// There is no bridge the other way round: the JSON-RPC server has no public services of
+// its own, and its admin namespace is meant for the IPC endpoint only, so it's not
+// exposed over gRPC or the gateway.
+//
+// Calls are made through a client connection to the in-process listener, which serves
+// the same gRPC server as the public listener, so they pass the same interceptors as
+// remote gRPC clients. Services in bridgeExcluded are not bridged.
+func (g *Server) JSONRPCApis() ([]jsonrpc2.API, error) {
+	conn, err := g.bridgeConn()
+	if err != nil {
+		return nil, err
+	}
+	var apis []jsonrpc2.API
+	for name := range g.rpc.GetServiceInfo() {
+		if bridgeExcluded[name] {
+			continue
+		}
+		desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			g.logger.Warnf("bridge: no descriptor of %s: %s", name, err)
+			continue
+		}
+		sd, ok := desc.(protoreflect.ServiceDescriptor)
+		if !ok {
+			continue
+		}
+		methods, err := bridgeMethods(conn, sd)
+		if err != nil {
+			return nil, fmt.Errorf("bridge %s: %s", name, err)
+		}
+		if len(methods) == 0 {
+			continue
+		}
+		apis = append(apis, jsonrpc2.API{
+			Namespace: bridgeNamespace(sd),
+			Version:   "1.0",
+			Service:   methods,
+			Public:    true,
+		})
+		g.logger.Debugf("bridge: %s served as %s", name, bridgeNamespace(sd))
+	}
+	return apis, nil
+}
+
+// bridgeConn returns the client connection used by the bridge, dialing the in-process
+// listener on first use.
+func (g *Server) bridgeConn() (*grpc.ClientConn, error) {
+	g.bridgeMu.Lock()
+	defer g.bridgeMu.Unlock()
+
+	if g.bridge != nil {
+		return g.bridge, nil
+	}
+	conn, err := grpc.Dial("bridge", g.dialOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("bridge dial: %s", err)
+	}
+	g.bridge = conn
+	return conn, nil
+}
+
+// bridgeFunc is a bridged JSON-RPC method.
+type bridgeFunc func(ctx context.Context, params *json.RawMessage) (json.RawMessage, error)
+
+// bridgeMethods returns the unary methods of sd as JSON-RPC methods.
+func bridgeMethods(conn *grpc.ClientConn, sd protoreflect.ServiceDescriptor) (jsonrpc2.Methods, error) {
+	methods := make(jsonrpc2.Methods)
+	for i := 0; i < sd.Methods().Len(); i++ {
+		md := sd.Methods().Get(i)
+		if md.IsStreamingClient() || md.IsStreamingServer() {
+			continue
+		}
+		in, err := protoregistry.GlobalTypes.FindMessageByName(md.Input().FullName())
+		if err != nil {
+			return nil, err
+		}
+		out, err := protoregistry.GlobalTypes.FindMessageByName(md.Output().FullName())
+		if err != nil {
+			return nil, err
+		}
+		fullName := fmt.Sprintf("/%s/%s", sd.FullName(), md.Name())
+		methods[lowerFirst(string(md.Name()))] = bridgeMethod(conn, fullName, in, out)
+	}
+	for alias, name := range bridgeAliases[sd.FullName()] {
+		method, ok := methods[lowerFirst(string(name))].(bridgeFunc)
+		if !ok {
+			return nil, fmt.Errorf("alias %s of unknown method %s", alias, name)
+		}
+		methods[alias] = valueMethod(method)
+	}
+	return methods, nil
+}
+
+// valueMethod returns a method returning the value field of the result of method.
+func valueMethod(method bridgeFunc) bridgeFunc {
+	return func(ctx context.Context, params *json.RawMessage) (json.RawMessage, error) {
+		result, err := method(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		var wrapper struct {
+			Value json.RawMessage `json:"value"`
+		}
+		if err := json.Unmarshal(result, &wrapper); err != nil {
+			return nil, err
+		}
+		return wrapper.Value, nil
+	}
+}
+
+// bridgeMethod returns a JSON-RPC method invoking the gRPC method fullName.
+func bridgeMethod(conn *grpc.ClientConn, fullName string, in, out protoreflect.MessageType) bridgeFunc {
+	return func(ctx context.Context, params *json.RawMessage) (json.RawMessage, error) {
+		req := in.New().Interface()
+		if params != nil && string(*params) != "null" {
+			if err := bridgeUnmarshal.Unmarshal(*params, req); err != nil {
+				return nil, &bridgeError{code: -32602, message: fmt.Sprintf("invalid params: %s", err)}
+			}
+		}
+		resp := out.New().Interface()
+		if err := conn.Invoke(ctx, fullName, protov1.MessageV1(req), protov1.MessageV1(resp)); err != nil {
+			return nil, newBridgeError(err)
+		}
+		return bridgeMarshal.Marshal(resp)
+	}
+}
+
+// bridgeNamespace derives the JSON-RPC namespace of a service, proto.PingAPI becomes ping.
+func bridgeNamespace(sd protoreflect.ServiceDescriptor) string {
+	name := string(sd.Name())
+	for _, suffix := range []string{"API", "Api", "Service"} {
+		if strings.HasSuffix(name, suffix) && len(name) > len(suffix) {
+			name = strings.TrimSuffix(name, suffix)
+			break
+		}
+	}
+	return strings.ToLower(name)
+}
+
+func lowerFirst(name string) string {
+	r := []rune(name)
+	if len(r) > 0 {
+		r[0] = unicode.ToLower(r[0])
+	}
+	return string(r)
+}
+
+// bridgeError carries a gRPC status to JSON-RPC clients.
+type bridgeError struct {
+	code    int
+	message string
+}
+
+func (e *bridgeError) Error() string  { return e.message }
+func (e *bridgeError) ErrorCode() int { return e.code }
+
+func newBridgeError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	code := -32000
+	switch st.Code() {
+	case codes.InvalidArgument:
+		code = -32602
+	case codes.Unimplemented:
+		code = -32601
+	}
+	return &bridgeError{code: code, message: st.Message()}
+}
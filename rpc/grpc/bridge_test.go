@@ -0,0 +1,151 @@
+package grpc
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drip/beyond/config"
+	jsonrpc2 "github.com/drip/beyond/pkg/jsonrpc2"
+	pb "github.com/drip/beyond/rpc/grpc/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+type testPingApi struct {
+	up bool
+}
+
+func (testPingApi) Info(context.Context, *empty.Empty) (*pb.String, error) {
+	return &pb.String{Value: "ping.info"}, nil
+}
+
+func (p testPingApi) Status(context.Context, *empty.Empty) (*pb.Boolean, error) {
+	if p.up {
+		return &pb.Boolean{Value: true}, nil
+	}
+	return nil, status.Error(codes.Unavailable, "node down")
+}
+
+func TestBridge(t *testing.T) {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	pb.RegisterPingAPIServer(srv, testPingApi{up: true})
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName("proto.PingAPI")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sd := desc.(protoreflect.ServiceDescriptor)
+	if ns := bridgeNamespace(sd); ns != "ping" {
+		t.Fatalf("namespace %s, want ping", ns)
+	}
+	methods, err := bridgeMethods(conn, sd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := jsonrpc2.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("ping", methods); err != nil {
+		t.Fatal(err)
+	}
+	client := jsonrpc2.DialInProc(server)
+	defer client.Close()
+
+	var info pb.String
+	if err := client.Call(&info, "ping_info"); err != nil {
+		t.Fatal(err)
+	}
+	if info.Value != "ping.info" {
+		t.Errorf("info %q, want ping.info", info.Value)
+	}
+	var up bool
+	if err := client.Call(&up, "ping_state"); err != nil || !up {
+		t.Errorf("state %v: %v, want true", up, err)
+	}
+	err = client.Call(nil, "ping_info", "bad")
+	if e, ok := err.(jsonrpc2.Error); !ok || e.ErrorCode() != -32602 {
+		t.Errorf("info with bad params: %v, want invalid params", err)
+	}
+}
+
+// TestBridgeServer calls the services of a Server through its JSON-RPC APIs.
+func TestBridgeServer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bridge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfg := &config.Config{
+		Endpoint: "http://127.0.0.1:1",
+		GRPCCfg: &config.GRPCCfg{
+			ListenAddress:   "unix://" + filepath.Join(dir, "probes.sock"),
+			GRPCDisabled:    true,
+			GatewayDisabled: true,
+		},
+	}
+	srv, err := NewServer(cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Stop()
+	apis, err := srv.JSONRPCApis()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := jsonrpc2.NewServer()
+	defer server.Stop()
+	for _, api := range apis {
+		if api.Namespace == "health" {
+			t.Error("health service bridged")
+		}
+		if err := server.RegisterName(api.Namespace, api.Service); err != nil {
+			t.Fatal(err)
+		}
+	}
+	client := jsonrpc2.DialInProc(server)
+	defer client.Close()
+
+	var info struct {
+		Value string `json:"value"`
+	}
+	if err := client.Call(&info, "ping_info"); err != nil {
+		t.Fatal(err)
+	}
+	if info.Value != "ping.info" {
+		t.Errorf("info %q, want ping.info", info.Value)
+	}
+	// the QLC node is unreachable
+	var up bool
+	if err := client.Call(&up, "ping_state"); err == nil {
+		t.Error("state of unreachable node without error")
+	}
+	// streams are not bridged
+	err = client.Call(nil, "event_watchActions")
+	if e, ok := err.(jsonrpc2.Error); !ok || e.ErrorCode() != -32601 {
+		t.Errorf("streaming method: %v, want method not found", err)
+	}
+}
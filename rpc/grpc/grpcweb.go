@@ -0,0 +1,40 @@
+package grpc
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"google.golang.org/grpc"
+)
+
+// newGRPCWebHandler serves grpc-web and grpc-web-text calls of browser clients with rpc,
+// including server streams, and all other requests with next. Cross-origin calls are
+// allowed from the CORS origins of the gateway.
+func newGRPCWebHandler(rpc *grpc.Server, next http.Handler, allowedOrigins []string) http.Handler {
+	web := grpcweb.WrapServer(rpc,
+		grpcweb.WithOriginFunc(originFunc(allowedOrigins)),
+		grpcweb.WithAllowedRequestHeaders([]string{"*"}),
+		// preflight requests of other paths are answered by the gateway
+		grpcweb.WithCorsForRegisteredEndpointsOnly(true))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if web.IsGrpcWebRequest(r) || web.IsAcceptableGrpcCorsRequest(r) {
+			web.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// originFunc matches origins like the CORS handler of the gateway, "*" allows all
+// origins and no origins only allow same-origin calls.
+func originFunc(allowedOrigins []string) func(string) bool {
+	return func(origin string) bool {
+		for _, o := range allowedOrigins {
+			if o == "*" || strings.EqualFold(o, origin) {
+				return true
+			}
+		}
+		return false
+	}
+}
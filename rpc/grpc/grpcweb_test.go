@@ -0,0 +1,91 @@
+package grpc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pb "github.com/drip/beyond/rpc/grpc/proto"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+func TestGRPCWeb(t *testing.T) {
+	rpc := grpc.NewServer()
+	pb.RegisterPingAPIServer(rpc, testPingApi{})
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	srv := httptest.NewServer(newGRPCWebHandler(rpc, next, []string{"http://dashboard"}))
+	defer srv.Close()
+
+	// an empty request message is a frame without payload
+	frame := make([]byte, 5)
+	for _, tc := range []struct {
+		contentType string
+		body        []byte
+	}{
+		{"application/grpc-web+proto", frame},
+		{"application/grpc-web-text", []byte(base64.StdEncoding.EncodeToString(frame))},
+	} {
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+"/proto.PingAPI/Info", bytes.NewReader(tc.body))
+		req.Header.Set("Content-Type", tc.contentType)
+		req.Header.Set("Origin", "http://dashboard")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || resp.Header.Get("Access-Control-Allow-Origin") != "http://dashboard" {
+			t.Fatalf("%s: status %d, headers %v", tc.contentType, resp.StatusCode, resp.Header)
+		}
+		if strings.HasSuffix(tc.contentType, "-text") {
+			if body, err = decodeText(body); err != nil {
+				t.Fatalf("%s: %s", tc.contentType, err)
+			}
+		}
+		if len(body) < 5 || body[0] != 0 {
+			t.Fatalf("%s: unexpected response %q", tc.contentType, body)
+		}
+		n := binary.BigEndian.Uint32(body[1:5])
+		var info pb.String
+		if err := proto.Unmarshal(body[5:5+n], &info); err != nil {
+			t.Fatal(err)
+		}
+		if info.Value != "ping.info" {
+			t.Errorf("%s: info %q", tc.contentType, info.Value)
+		}
+		if trailer := string(body[5+n:]); !strings.Contains(trailer, "grpc-status: 0") && !strings.Contains(trailer, "Grpc-Status: 0") {
+			t.Errorf("%s: trailer %q", tc.contentType, trailer)
+		}
+	}
+
+	// other requests are passed on
+	resp, err := http.Get(srv.URL + "/ping/info")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTeapot {
+		t.Errorf("gateway request: status %d", resp.StatusCode)
+	}
+}
+
+// decodeText decodes a grpc-web-text response, which consists of padded base64 chunks.
+func decodeText(body []byte) ([]byte, error) {
+	var out []byte
+	for i := 0; i+4 <= len(body); i += 4 {
+		b, err := base64.StdEncoding.DecodeString(string(body[i : i+4]))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, b...)
+	}
+	return out, nil
+}
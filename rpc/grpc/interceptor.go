@@ -0,0 +1,166 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDKey is the metadata key carrying the request ID. IDs sent by clients are kept,
+// otherwise one is generated. It is returned in the response headers.
+const RequestIDKey = "x-request-id"
+
+type requestIDKey struct{}
+
+// RequestID returns the request ID of a call.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// withRequestID stores the request ID of the incoming metadata or a new one in ctx.
+func withRequestID(ctx context.Context) (context.Context, string) {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDKey); len(ids) > 0 {
+			id = ids[0]
+		}
+	}
+	if id == "" || len(id) > 128 {
+		id = newRequestID()
+	}
+	return context.WithValue(ctx, requestIDKey{}, id), id
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// deadlines applies the configured server-side deadlines.
+type deadlines struct {
+	def     time.Duration
+	max     time.Duration
+	methods map[string]time.Duration
+}
+
+// lookup returns the deadline configured for method, by full name or service prefix.
+func (d *deadlines) lookup(method string) (time.Duration, bool) {
+	if v, ok := d.methods[method]; ok {
+		return v, true
+	}
+	if i := strings.LastIndex(method, "/"); i > 0 {
+		if v, ok := d.methods[method[:i+1]]; ok {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// apply limits ctx to the deadline of method. Streams are only limited by method
+// deadlines, as most of them are long-lived.
+func (d *deadlines) apply(ctx context.Context, method string, stream bool) (context.Context, context.CancelFunc) {
+	limit, ok := d.lookup(method)
+	if !ok {
+		if stream {
+			return ctx, func() {}
+		}
+		limit = d.max
+		if _, has := ctx.Deadline(); !has && d.def > 0 {
+			limit = d.def
+		}
+	}
+	if limit <= 0 {
+		return ctx, func() {}
+	}
+	if deadline, has := ctx.Deadline(); has && time.Until(deadline) <= limit {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, limit)
+}
+
+// interceptors logs calls, recovers from panics and applies deadlines.
+type interceptors struct {
+	deadlines *deadlines
+	logger    *zap.SugaredLogger
+}
+
+func (i *interceptors) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	start := time.Now()
+	ctx, id := withRequestID(ctx)
+	grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, id))
+	defer func() {
+		i.log(ctx, info.FullMethod, id, "unary", start, err)
+	}()
+	defer i.recover(info.FullMethod, id, &err)
+
+	ctx, cancel := i.deadlines.apply(ctx, info.FullMethod, false)
+	defer cancel()
+	return handler(ctx, req)
+}
+
+func (i *interceptors) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	start := time.Now()
+	ctx, id := withRequestID(ss.Context())
+	ss.SetHeader(metadata.Pairs(RequestIDKey, id))
+	defer func() {
+		i.log(ctx, info.FullMethod, id, "stream", start, err)
+	}()
+	defer i.recover(info.FullMethod, id, &err)
+
+	ctx, cancel := i.deadlines.apply(ctx, info.FullMethod, true)
+	defer cancel()
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+// recover turns a panic of the handler into codes.Internal, like JSON-RPC callbacks.
+func (i *interceptors) recover(method, id string, err *error) {
+	if r := recover(); r != nil {
+		i.logger.Errorw("grpc handler crashed", "method", method, "requestId", id, "panic", r, "stack", string(debug.Stack()))
+		*err = status.Errorf(codes.Internal, "internal error, request id %s", id)
+	}
+}
+
+// log writes the access log entry of a call.
+func (i *interceptors) log(ctx context.Context, method, id, kind string, start time.Time, err error) {
+	code := status.Code(err)
+	fields := []interface{}{
+		"method", method,
+		"type", kind,
+		"code", code.String(),
+		"duration", time.Since(start),
+		"requestId", id,
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		fields = append(fields, "peer", p.Addr.String())
+	}
+	switch code {
+	case codes.OK, codes.Canceled, codes.NotFound, codes.InvalidArgument, codes.AlreadyExists, codes.OutOfRange:
+		i.logger.Infow("grpc call", fields...)
+	case codes.Internal, codes.Unknown, codes.DataLoss:
+		i.logger.Errorw("grpc call", append(fields, "error", err.Error())...)
+	default:
+		i.logger.Warnw("grpc call", append(fields, "error", err.Error())...)
+	}
+}
+
+// contextStream replaces the context of a server stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/drip/beyond/rpc/grpc/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type interceptorPingApi struct {
+	deadline chan time.Duration
+}
+
+func (interceptorPingApi) Info(context.Context, *empty.Empty) (*pb.String, error) {
+	panic("boom")
+}
+
+func (a interceptorPingApi) Status(ctx context.Context, _ *empty.Empty) (*pb.Boolean, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		a.deadline <- 0
+	} else {
+		a.deadline <- time.Until(deadline)
+	}
+	return &pb.Boolean{Value: RequestID(ctx) != ""}, nil
+}
+
+func TestInterceptors(t *testing.T) {
+	i := &interceptors{
+		deadlines: &deadlines{def: time.Second, max: time.Minute, methods: map[string]time.Duration{}},
+		logger:    zap.NewNop().Sugar(),
+	}
+	api := interceptorPingApi{deadline: make(chan time.Duration, 1)}
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.UnaryInterceptor(i.unary), grpc.StreamInterceptor(i.stream))
+	pb.RegisterPingAPIServer(srv, api)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewPingAPIClient(conn)
+
+	// panics are reported as internal errors
+	var header metadata.MD
+	_, err = client.Info(context.Background(), &empty.Empty{}, grpc.Header(&header))
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("info error %v, want internal", err)
+	}
+	if ids := header.Get(RequestIDKey); len(ids) != 1 || ids[0] == "" {
+		t.Errorf("request id header %v", ids)
+	}
+
+	// request ids of the client are kept, the default deadline is applied
+	ctx := metadata.AppendToOutgoingContext(context.Background(), RequestIDKey, "abc")
+	res, err := client.Status(ctx, &empty.Empty{}, grpc.Header(&header))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Value {
+		t.Error("request id missing in handler")
+	}
+	if ids := header.Get(RequestIDKey); len(ids) != 1 || ids[0] != "abc" {
+		t.Errorf("request id header %v, want abc", ids)
+	}
+	if d := <-api.deadline; d <= 0 || d > time.Second {
+		t.Errorf("deadline %s, want default of 1s", d)
+	}
+
+	// deadlines of clients are capped by method deadlines
+	i.deadlines.methods["/proto.PingAPI/"] = 100 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	if _, err := client.Status(ctx, &empty.Empty{}); err != nil {
+		t.Fatal(err)
+	}
+	if d := <-api.deadline; d <= 0 || d > 100*time.Millisecond {
+		t.Errorf("deadline %s, want 100ms of the service", d)
+	}
+}
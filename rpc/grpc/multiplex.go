@@ -0,0 +1,35 @@
+package grpc
+
+import (
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+)
+
+// newMultiplexHandler serves gRPC requests with rpc and all other requests with gateway.
+// HTTP/2 is accepted without TLS (h2c), which plaintext gRPC clients use.
+//
+// gRPC is served through the net/http HTTP/2 server then, so the keepalive and
+// connection settings of the gRPC server do not apply to these connections.
+func newMultiplexHandler(rpc *grpc.Server, gateway http.Handler) http.Handler {
+	return h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isGRPC(r) {
+			rpc.ServeHTTP(w, r)
+			return
+		}
+		gateway.ServeHTTP(w, r)
+	}), &http2.Server{})
+}
+
+// isGRPC reports whether r is a gRPC call, e.g. with content type application/grpc or
+// application/grpc+proto. gRPC-Web calls are served by the gateway.
+func isGRPC(r *http.Request) bool {
+	if r.ProtoMajor != 2 {
+		return false
+	}
+	ct := r.Header.Get("Content-Type")
+	return ct == "application/grpc" || strings.HasPrefix(ct, "application/grpc+") || strings.HasPrefix(ct, "application/grpc;")
+}
@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	"github.com/drip/beyond/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/keepalive"
+)
+
+// serverOptions converts the transport settings to server options, invalid settings are
+// rejected with an error.
+func serverOptions(cfg *config.ServerCfg) ([]grpc.ServerOption, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	var opts []grpc.ServerOption
+	if cfg.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(cfg.MaxRecvMsgSize))
+	}
+	if cfg.MaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(cfg.MaxSendMsgSize))
+	}
+	if cfg.MaxConcurrentStreams > 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(cfg.MaxConcurrentStreams))
+	}
+	timeout, err := cfg.ConnTimeout()
+	if err != nil {
+		return nil, err
+	}
+	if timeout > 0 {
+		opts = append(opts, grpc.ConnectionTimeout(timeout))
+	}
+	ka, err := cfg.Keepalive.Params()
+	if err != nil {
+		return nil, err
+	}
+	if ka == nil {
+		return opts, nil
+	}
+	opts = append(opts, grpc.KeepaliveParams(keepalive.ServerParameters{
+		MaxConnectionIdle:     ka.MaxConnectionIdle,
+		MaxConnectionAge:      ka.MaxConnectionAge,
+		MaxConnectionAgeGrace: ka.MaxConnectionAgeGrace,
+		Time:                  ka.Time,
+		Timeout:               ka.Timeout,
+	}))
+	if ka.MinTime > 0 || ka.PermitWithoutStream {
+		opts = append(opts, grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             ka.MinTime,
+			PermitWithoutStream: ka.PermitWithoutStream,
+		}))
+	}
+	return opts, nil
+}
+
+// clientOptions returns the dial options of the gateway and the JSON-RPC bridge, which
+// match the limits of the server. They connect to the in-process listener, so they don't
+// send keepalive pings.
+func clientOptions(cfg *config.ServerCfg) []grpc.DialOption {
+	if cfg == nil {
+		return nil
+	}
+	var opts []grpc.DialOption
+	var callOpts []grpc.CallOption
+	// the client receives what the server sends and vice versa
+	if cfg.MaxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(cfg.MaxSendMsgSize))
+	}
+	if cfg.MaxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(cfg.MaxRecvMsgSize))
+	}
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+	if timeout, err := cfg.ConnTimeout(); err == nil && timeout > 0 {
+		opts = append(opts, grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff:           backoff.DefaultConfig,
+			MinConnectTimeout: timeout,
+		}))
+	}
+	return opts
+}
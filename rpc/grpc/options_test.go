@@ -0,0 +1,61 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/drip/beyond/config"
+	pb "github.com/drip/beyond/rpc/grpc/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestServerOptions(t *testing.T) {
+	cfg := &config.ServerCfg{
+		MaxSendMsgSize:    4,
+		ConnectionTimeout: "5s",
+		Keepalive:         &config.KeepaliveCfg{Time: "1s", MinTime: "10s"},
+	}
+	if err := cfg.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	opts, err := serverOptions(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(opts...)
+	pb.RegisterPingAPIServer(srv, testPingApi{})
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	dialOpts := append(clientOptions(cfg), grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}))
+	conn, err := grpc.Dial("bufnet", dialOpts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	_, err = pb.NewPingAPIClient(conn).Info(context.Background(), &empty.Empty{})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("info error %v, want resource exhausted", err)
+	}
+
+	// clients may ping as often as the server without a min time
+	ka, err := (&config.KeepaliveCfg{Time: "30s"}).Params()
+	if err != nil || ka.MinTime != 30*time.Second {
+		t.Errorf("default min time %v, %v", ka, err)
+	}
+
+	bad := &config.ServerCfg{Keepalive: &config.KeepaliveCfg{MaxConnectionAgeGrace: "1m"}}
+	if err := bad.Verify(); err == nil {
+		t.Error("grace without max connection age accepted")
+	}
+}
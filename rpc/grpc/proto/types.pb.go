@@ -10,6 +10,7 @@ import (
 	context "context"
 	proto "github.com/golang/protobuf/proto"
 	empty "github.com/golang/protobuf/ptypes/empty"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -31,6 +32,104 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+type ActionSort int32
+
+const (
+	ActionSort_TIME   ActionSort = 0
+	ActionSort_PROFIT ActionSort = 1
+	ActionSort_GAS    ActionSort = 2
+)
+
+// Enum value maps for ActionSort.
+var (
+	ActionSort_name = map[int32]string{
+		0: "TIME",
+		1: "PROFIT",
+		2: "GAS",
+	}
+	ActionSort_value = map[string]int32{
+		"TIME":   0,
+		"PROFIT": 1,
+		"GAS":    2,
+	}
+)
+
+func (x ActionSort) Enum() *ActionSort {
+	p := new(ActionSort)
+	*p = x
+	return p
+}
+
+func (x ActionSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ActionSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_types_proto_enumTypes[0].Descriptor()
+}
+
+func (ActionSort) Type() protoreflect.EnumType {
+	return &file_types_proto_enumTypes[0]
+}
+
+func (x ActionSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ActionSort.Descriptor instead.
+func (ActionSort) EnumDescriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{0}
+}
+
+type TimeBucket int32
+
+const (
+	TimeBucket_DAY  TimeBucket = 0
+	TimeBucket_HOUR TimeBucket = 1
+	TimeBucket_WEEK TimeBucket = 2
+)
+
+// Enum value maps for TimeBucket.
+var (
+	TimeBucket_name = map[int32]string{
+		0: "DAY",
+		1: "HOUR",
+		2: "WEEK",
+	}
+	TimeBucket_value = map[string]int32{
+		"DAY":  0,
+		"HOUR": 1,
+		"WEEK": 2,
+	}
+)
+
+func (x TimeBucket) Enum() *TimeBucket {
+	p := new(TimeBucket)
+	*p = x
+	return p
+}
+
+func (x TimeBucket) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TimeBucket) Descriptor() protoreflect.EnumDescriptor {
+	return file_types_proto_enumTypes[1].Descriptor()
+}
+
+func (TimeBucket) Type() protoreflect.EnumType {
+	return &file_types_proto_enumTypes[1]
+}
+
+func (x TimeBucket) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TimeBucket.Descriptor instead.
+func (TimeBucket) EnumDescriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{1}
+}
+
 type Boolean struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -125,222 +224,2597 @@ func (x *String) GetValue() string {
 	return ""
 }
 
-var File_types_proto protoreflect.FileDescriptor
+type WatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_types_proto_rawDesc = []byte{
-	0x0a, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69,
-	0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
-	0x1f, 0x0a, 0x07, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x22, 0x1e, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x32, 0x94, 0x01, 0x0a, 0x07, 0x50, 0x69, 0x6e, 0x67, 0x41, 0x50, 0x49, 0x12, 0x41, 0x0a, 0x04,
-	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0d, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x22, 0x12, 0x82, 0xd3, 0xe4,
-	0x93, 0x02, 0x0c, 0x12, 0x0a, 0x2f, 0x70, 0x69, 0x6e, 0x67, 0x2f, 0x69, 0x6e, 0x66, 0x6f, 0x12,
-	0x46, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x1a, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61,
-	0x6e, 0x22, 0x14, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x12, 0x0c, 0x2f, 0x70, 0x69, 0x6e, 0x67,
-	0x2f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x3b, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	// resume after this sequence, 0 receives new events only
+	FromSequence uint64 `protobuf:"varint,1,opt,name=from_sequence,json=fromSequence,proto3" json:"from_sequence,omitempty"`
 }
 
-var (
-	file_types_proto_rawDescOnce sync.Once
-	file_types_proto_rawDescData = file_types_proto_rawDesc
-)
-
-func file_types_proto_rawDescGZIP() []byte {
-	file_types_proto_rawDescOnce.Do(func() {
-		file_types_proto_rawDescData = protoimpl.X.CompressGZIP(file_types_proto_rawDescData)
-	})
-	return file_types_proto_rawDescData
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-var file_types_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
-var file_types_proto_goTypes = []interface{}{
-	(*Boolean)(nil),     // 0: proto.Boolean
-	(*String)(nil),      // 1: proto.String
-	(*empty.Empty)(nil), // 2: google.protobuf.Empty
-}
-var file_types_proto_depIdxs = []int32{
-	2, // 0: proto.PingAPI.Info:input_type -> google.protobuf.Empty
-	2, // 1: proto.PingAPI.Status:input_type -> google.protobuf.Empty
-	1, // 2: proto.PingAPI.Info:output_type -> proto.String
-	0, // 3: proto.PingAPI.Status:output_type -> proto.Boolean
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_types_proto_init() }
-func file_types_proto_init() {
-	if File_types_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_types_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Boolean); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_types_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*String); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_types_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   2,
-			NumExtensions: 0,
-			NumServices:   1,
-		},
-		GoTypes:           file_types_proto_goTypes,
-		DependencyIndexes: file_types_proto_depIdxs,
-		MessageInfos:      file_types_proto_msgTypes,
-	}.Build()
-	File_types_proto = out.File
-	file_types_proto_rawDesc = nil
-	file_types_proto_goTypes = nil
-	file_types_proto_depIdxs = nil
+	return mi.MessageOf(x)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{2}
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+func (x *WatchRequest) GetFromSequence() uint64 {
+	if x != nil {
+		return x.FromSequence
+	}
+	return 0
+}
 
-// PingAPIClient is the client API for PingAPI service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type PingAPIClient interface {
-	Info(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*String, error)
-	Status(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Boolean, error)
+type ListActionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// starts at 1, 0 selects the first page
+	Page int32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	// 10 by default, at most 100
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// filters, unset ones match all actions
+	Pair        string              `protobuf:"bytes,3,opt,name=pair,proto3" json:"pair,omitempty"`
+	IncludeSake *wrappers.BoolValue `protobuf:"bytes,4,opt,name=include_sake,json=includeSake,proto3" json:"include_sake,omitempty"`
+	// created at or after since and before until, unix milliseconds
+	Since int64 `protobuf:"varint,5,opt,name=since,proto3" json:"since,omitempty"`
+	Until int64 `protobuf:"varint,6,opt,name=until,proto3" json:"until,omitempty"`
+	// profit within [min_profit, max_profit], exact decimals
+	MinProfit string              `protobuf:"bytes,7,opt,name=min_profit,json=minProfit,proto3" json:"min_profit,omitempty"`
+	MaxProfit string              `protobuf:"bytes,8,opt,name=max_profit,json=maxProfit,proto3" json:"max_profit,omitempty"`
+	HasTxHash *wrappers.BoolValue `protobuf:"bytes,9,opt,name=has_tx_hash,json=hasTxHash,proto3" json:"has_tx_hash,omitempty"`
+	// newest actions first by default
+	Sort      ActionSort `protobuf:"varint,10,opt,name=sort,proto3,enum=proto.ActionSort" json:"sort,omitempty"`
+	Ascending bool       `protobuf:"varint,11,opt,name=ascending,proto3" json:"ascending,omitempty"`
+	// next_cursor of the previous page, page is ignored if set
+	Cursor string `protobuf:"bytes,12,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// status of the transaction: pending, confirmed, failed or dropped, unknown for
+	// swaps recorded before transactions were tracked
+	Status string `protobuf:"bytes,13,opt,name=status,proto3" json:"status,omitempty"`
 }
 
-type pingAPIClient struct {
-	cc grpc.ClientConnInterface
+func (x *ListActionsRequest) Reset() {
+	*x = ListActionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func NewPingAPIClient(cc grpc.ClientConnInterface) PingAPIClient {
-	return &pingAPIClient{cc}
+func (x *ListActionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (c *pingAPIClient) Info(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*String, error) {
-	out := new(String)
-	err := c.cc.Invoke(ctx, "/proto.PingAPI/Info", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (*ListActionsRequest) ProtoMessage() {}
+
+func (x *ListActionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return out, nil
+	return mi.MessageOf(x)
 }
 
-func (c *pingAPIClient) Status(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Boolean, error) {
-	out := new(Boolean)
-	err := c.cc.Invoke(ctx, "/proto.PingAPI/Status", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+// Deprecated: Use ListActionsRequest.ProtoReflect.Descriptor instead.
+func (*ListActionsRequest) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{3}
 }
 
-// PingAPIServer is the server API for PingAPI service.
-type PingAPIServer interface {
-	Info(context.Context, *empty.Empty) (*String, error)
-	Status(context.Context, *empty.Empty) (*Boolean, error)
+func (x *ListActionsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
 }
 
-// UnimplementedPingAPIServer can be embedded to have forward compatible implementations.
-type UnimplementedPingAPIServer struct {
+func (x *ListActionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
 }
 
-func (*UnimplementedPingAPIServer) Info(context.Context, *empty.Empty) (*String, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Info not implemented")
+func (x *ListActionsRequest) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
 }
-func (*UnimplementedPingAPIServer) Status(context.Context, *empty.Empty) (*Boolean, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+
+func (x *ListActionsRequest) GetIncludeSake() *wrappers.BoolValue {
+	if x != nil {
+		return x.IncludeSake
+	}
+	return nil
 }
 
-func RegisterPingAPIServer(s *grpc.Server, srv PingAPIServer) {
-	s.RegisterService(&_PingAPI_serviceDesc, srv)
+func (x *ListActionsRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
 }
 
-func _PingAPI_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
-	if err := dec(in); err != nil {
-		return nil, err
+func (x *ListActionsRequest) GetUntil() int64 {
+	if x != nil {
+		return x.Until
 	}
-	if interceptor == nil {
-		return srv.(PingAPIServer).Info(ctx, in)
+	return 0
+}
+
+func (x *ListActionsRequest) GetMinProfit() string {
+	if x != nil {
+		return x.MinProfit
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/proto.PingAPI/Info",
+	return ""
+}
+
+func (x *ListActionsRequest) GetMaxProfit() string {
+	if x != nil {
+		return x.MaxProfit
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PingAPIServer).Info(ctx, req.(*empty.Empty))
+	return ""
+}
+
+func (x *ListActionsRequest) GetHasTxHash() *wrappers.BoolValue {
+	if x != nil {
+		return x.HasTxHash
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _PingAPI_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
-	if err := dec(in); err != nil {
-		return nil, err
+func (x *ListActionsRequest) GetSort() ActionSort {
+	if x != nil {
+		return x.Sort
 	}
-	if interceptor == nil {
-		return srv.(PingAPIServer).Status(ctx, in)
+	return ActionSort_TIME
+}
+
+func (x *ListActionsRequest) GetAscending() bool {
+	if x != nil {
+		return x.Ascending
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/proto.PingAPI/Status",
+	return false
+}
+
+func (x *ListActionsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PingAPIServer).Status(ctx, req.(*empty.Empty))
+	return ""
+}
+
+func (x *ListActionsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-var _PingAPI_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "proto.PingAPI",
-	HandlerType: (*PingAPIServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Info",
-			Handler:    _PingAPI_Info_Handler,
+type ActionPage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Actions []*Action `protobuf:"bytes,1,rep,name=actions,proto3" json:"actions,omitempty"`
+	// actions of all pages
+	Total      int64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page       int32 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize   int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	TotalPages int32 `protobuf:"varint,5,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	// continues after this page, stable while actions are inserted. Empty on the last page.
+	NextCursor string `protobuf:"bytes,6,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+}
+
+func (x *ActionPage) Reset() {
+	*x = ActionPage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionPage) ProtoMessage() {}
+
+func (x *ActionPage) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionPage.ProtoReflect.Descriptor instead.
+func (*ActionPage) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ActionPage) GetActions() []*Action {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *ActionPage) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ActionPage) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ActionPage) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ActionPage) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *ActionPage) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type ActionID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *ActionID) Reset() {
+	*x = ActionID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionID) ProtoMessage() {}
+
+func (x *ActionID) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionID.ProtoReflect.Descriptor instead.
+func (*ActionID) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ActionID) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type TxHash struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxHash string `protobuf:"bytes,1,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+}
+
+func (x *TxHash) Reset() {
+	*x = TxHash{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TxHash) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxHash) ProtoMessage() {}
+
+func (x *TxHash) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxHash.ProtoReflect.Descriptor instead.
+func (*TxHash) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TxHash) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+type StatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// all pairs if empty
+	Pair string `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	// created at or after since and before until, unix milliseconds
+	Since int64 `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`
+	Until int64 `protobuf:"varint,3,opt,name=until,proto3" json:"until,omitempty"`
+}
+
+func (x *StatsRequest) Reset() {
+	*x = StatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsRequest) ProtoMessage() {}
+
+func (x *StatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsRequest.ProtoReflect.Descriptor instead.
+func (*StatsRequest) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StatsRequest) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *StatsRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *StatsRequest) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+// Profits are exact decimals, the average is rounded to 18 fractional digits.
+type TradeStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Trades        int64  `protobuf:"varint,1,opt,name=trades,proto3" json:"trades,omitempty"`
+	TotalProfit   string `protobuf:"bytes,2,opt,name=total_profit,json=totalProfit,proto3" json:"total_profit,omitempty"`
+	AverageProfit string `protobuf:"bytes,3,opt,name=average_profit,json=averageProfit,proto3" json:"average_profit,omitempty"`
+	MedianProfit  string `protobuf:"bytes,4,opt,name=median_profit,json=medianProfit,proto3" json:"median_profit,omitempty"`
+	Gas           int64  `protobuf:"varint,5,opt,name=gas,proto3" json:"gas,omitempty"`
+	// share of trades with a positive profit
+	WinRate     float64 `protobuf:"fixed64,6,opt,name=win_rate,json=winRate,proto3" json:"win_rate,omitempty"`
+	BestProfit  string  `protobuf:"bytes,7,opt,name=best_profit,json=bestProfit,proto3" json:"best_profit,omitempty"`
+	WorstProfit string  `protobuf:"bytes,8,opt,name=worst_profit,json=worstProfit,proto3" json:"worst_profit,omitempty"`
+}
+
+func (x *TradeStats) Reset() {
+	*x = TradeStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TradeStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TradeStats) ProtoMessage() {}
+
+func (x *TradeStats) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TradeStats.ProtoReflect.Descriptor instead.
+func (*TradeStats) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TradeStats) GetTrades() int64 {
+	if x != nil {
+		return x.Trades
+	}
+	return 0
+}
+
+func (x *TradeStats) GetTotalProfit() string {
+	if x != nil {
+		return x.TotalProfit
+	}
+	return ""
+}
+
+func (x *TradeStats) GetAverageProfit() string {
+	if x != nil {
+		return x.AverageProfit
+	}
+	return ""
+}
+
+func (x *TradeStats) GetMedianProfit() string {
+	if x != nil {
+		return x.MedianProfit
+	}
+	return ""
+}
+
+func (x *TradeStats) GetGas() int64 {
+	if x != nil {
+		return x.Gas
+	}
+	return 0
+}
+
+func (x *TradeStats) GetWinRate() float64 {
+	if x != nil {
+		return x.WinRate
+	}
+	return 0
+}
+
+func (x *TradeStats) GetBestProfit() string {
+	if x != nil {
+		return x.BestProfit
+	}
+	return ""
+}
+
+func (x *TradeStats) GetWorstProfit() string {
+	if x != nil {
+		return x.WorstProfit
+	}
+	return ""
+}
+
+type PairStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair  string      `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	Stats *TradeStats `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *PairStats) Reset() {
+	*x = PairStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PairStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PairStats) ProtoMessage() {}
+
+func (x *PairStats) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PairStats.ProtoReflect.Descriptor instead.
+func (*PairStats) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PairStats) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *PairStats) GetStats() *TradeStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type PairStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pairs []*PairStats `protobuf:"bytes,1,rep,name=pairs,proto3" json:"pairs,omitempty"`
+	// stats of all selected actions
+	Total *TradeStats `protobuf:"bytes,2,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *PairStatsResponse) Reset() {
+	*x = PairStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PairStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PairStatsResponse) ProtoMessage() {}
+
+func (x *PairStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PairStatsResponse.ProtoReflect.Descriptor instead.
+func (*PairStatsResponse) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PairStatsResponse) GetPairs() []*PairStats {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+func (x *PairStatsResponse) GetTotal() *TradeStats {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+type PnLRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair   string     `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	Since  int64      `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`
+	Until  int64      `protobuf:"varint,3,opt,name=until,proto3" json:"until,omitempty"`
+	Bucket TimeBucket `protobuf:"varint,4,opt,name=bucket,proto3,enum=proto.TimeBucket" json:"bucket,omitempty"`
+}
+
+func (x *PnLRequest) Reset() {
+	*x = PnLRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PnLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PnLRequest) ProtoMessage() {}
+
+func (x *PnLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PnLRequest.ProtoReflect.Descriptor instead.
+func (*PnLRequest) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PnLRequest) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *PnLRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *PnLRequest) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+func (x *PnLRequest) GetBucket() TimeBucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return TimeBucket_DAY
+}
+
+type PnLPoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// start of the bucket, unix milliseconds. Weeks start on monday, buckets are UTC.
+	Time             int64       `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	Stats            *TradeStats `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats,omitempty"`
+	CumulativeProfit string      `protobuf:"bytes,3,opt,name=cumulative_profit,json=cumulativeProfit,proto3" json:"cumulative_profit,omitempty"`
+}
+
+func (x *PnLPoint) Reset() {
+	*x = PnLPoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PnLPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PnLPoint) ProtoMessage() {}
+
+func (x *PnLPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PnLPoint.ProtoReflect.Descriptor instead.
+func (*PnLPoint) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PnLPoint) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *PnLPoint) GetStats() *TradeStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *PnLPoint) GetCumulativeProfit() string {
+	if x != nil {
+		return x.CumulativeProfit
+	}
+	return ""
+}
+
+type PnLResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// buckets with actions in order
+	Points []*PnLPoint `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+}
+
+func (x *PnLResponse) Reset() {
+	*x = PnLResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PnLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PnLResponse) ProtoMessage() {}
+
+func (x *PnLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PnLResponse.ProtoReflect.Descriptor instead.
+func (*PnLResponse) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PnLResponse) GetPoints() []*PnLPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+type Action struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Pair string `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	// amounts in tokens as exact decimals, e.g. 1.5
+	Input  string `protobuf:"bytes,3,opt,name=input,proto3" json:"input,omitempty"`
+	Output string `protobuf:"bytes,4,opt,name=output,proto3" json:"output,omitempty"`
+	Gas    int64  `protobuf:"varint,5,opt,name=gas,proto3" json:"gas,omitempty"`
+	Price  string `protobuf:"bytes,6,opt,name=price,proto3" json:"price,omitempty"`
+	// profit in the output token, exact decimal
+	Profit      string `protobuf:"bytes,7,opt,name=profit,proto3" json:"profit,omitempty"`
+	TxHash      string `protobuf:"bytes,8,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	IncludeSake bool   `protobuf:"varint,9,opt,name=include_sake,json=includeSake,proto3" json:"include_sake,omitempty"`
+	// unix milliseconds
+	CreatedAt int64 `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// amounts in the smallest unit of the tokens
+	InputUnits  string `protobuf:"bytes,12,opt,name=input_units,json=inputUnits,proto3" json:"input_units,omitempty"`
+	OutputUnits string `protobuf:"bytes,13,opt,name=output_units,json=outputUnits,proto3" json:"output_units,omitempty"`
+	// status of the transaction: pending, confirmed, failed or dropped, unknown for
+	// swaps recorded before transactions were tracked. Empty without a transaction.
+	Status string `protobuf:"bytes,14,opt,name=status,proto3" json:"status,omitempty"`
+	// unix milliseconds, 0 unless confirmed
+	ConfirmedAt int64 `protobuf:"varint,15,opt,name=confirmed_at,json=confirmedAt,proto3" json:"confirmed_at,omitempty"`
+	// gas of the confirmed transaction, gas is the estimate
+	GasUsed int64 `protobuf:"varint,16,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+}
+
+func (x *Action) Reset() {
+	*x = Action{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Action) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Action) ProtoMessage() {}
+
+func (x *Action) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Action.ProtoReflect.Descriptor instead.
+func (*Action) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Action) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Action) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *Action) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *Action) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *Action) GetGas() int64 {
+	if x != nil {
+		return x.Gas
+	}
+	return 0
+}
+
+func (x *Action) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Action) GetProfit() string {
+	if x != nil {
+		return x.Profit
+	}
+	return ""
+}
+
+func (x *Action) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *Action) GetIncludeSake() bool {
+	if x != nil {
+		return x.IncludeSake
+	}
+	return false
+}
+
+func (x *Action) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Action) GetInputUnits() string {
+	if x != nil {
+		return x.InputUnits
+	}
+	return ""
+}
+
+func (x *Action) GetOutputUnits() string {
+	if x != nil {
+		return x.OutputUnits
+	}
+	return ""
+}
+
+func (x *Action) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Action) GetConfirmedAt() int64 {
+	if x != nil {
+		return x.ConfirmedAt
+	}
+	return 0
+}
+
+func (x *Action) GetGasUsed() int64 {
+	if x != nil {
+		return x.GasUsed
+	}
+	return 0
+}
+
+type ActionEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sequence uint64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// unix milliseconds
+	Time   int64   `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	Action *Action `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+}
+
+func (x *ActionEvent) Reset() {
+	*x = ActionEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionEvent) ProtoMessage() {}
+
+func (x *ActionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionEvent.ProtoReflect.Descriptor instead.
+func (*ActionEvent) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ActionEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *ActionEvent) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *ActionEvent) GetAction() *Action {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+type Quote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair   string `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	Input  string `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	Output string `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
+	Price  string `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	Gas    int64  `protobuf:"varint,5,opt,name=gas,proto3" json:"gas,omitempty"`
+}
+
+func (x *Quote) Reset() {
+	*x = Quote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Quote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quote) ProtoMessage() {}
+
+func (x *Quote) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quote.ProtoReflect.Descriptor instead.
+func (*Quote) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Quote) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *Quote) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *Quote) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *Quote) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Quote) GetGas() int64 {
+	if x != nil {
+		return x.Gas
+	}
+	return 0
+}
+
+type QuoteEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sequence uint64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Time     int64  `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	Quote    *Quote `protobuf:"bytes,3,opt,name=quote,proto3" json:"quote,omitempty"`
+}
+
+func (x *QuoteEvent) Reset() {
+	*x = QuoteEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuoteEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteEvent) ProtoMessage() {}
+
+func (x *QuoteEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteEvent.ProtoReflect.Descriptor instead.
+func (*QuoteEvent) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *QuoteEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *QuoteEvent) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *QuoteEvent) GetQuote() *Quote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+type ChainStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Online bool   `protobuf:"varint,1,opt,name=online,proto3" json:"online,omitempty"`
+	Blocks uint64 `protobuf:"varint,2,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	Error  string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *ChainStatus) Reset() {
+	*x = ChainStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChainStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChainStatus) ProtoMessage() {}
+
+func (x *ChainStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChainStatus.ProtoReflect.Descriptor instead.
+func (*ChainStatus) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ChainStatus) GetOnline() bool {
+	if x != nil {
+		return x.Online
+	}
+	return false
+}
+
+func (x *ChainStatus) GetBlocks() uint64 {
+	if x != nil {
+		return x.Blocks
+	}
+	return 0
+}
+
+func (x *ChainStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ChainStatusEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sequence uint64       `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Time     int64        `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	Status   *ChainStatus `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *ChainStatusEvent) Reset() {
+	*x = ChainStatusEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChainStatusEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChainStatusEvent) ProtoMessage() {}
+
+func (x *ChainStatusEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChainStatusEvent.ProtoReflect.Descriptor instead.
+func (*ChainStatusEvent) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ChainStatusEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *ChainStatusEvent) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *ChainStatusEvent) GetStatus() *ChainStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+var File_types_proto protoreflect.FileDescriptor
+
+var file_types_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x1f, 0x0a, 0x07, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x22, 0x1e, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x22, 0x33, 0x0a, 0x0c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x23, 0x0a, 0x0d, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x65, 0x22, 0xb3, 0x03, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69,
+	0x72, 0x12, 0x3d, 0x0a, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x73, 0x61, 0x6b,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x0b, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x53, 0x61, 0x6b, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x1d, 0x0a, 0x0a,
+	0x6d, 0x69, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x6d, 0x69, 0x6e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6d,
+	0x61, 0x78, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x12, 0x3a, 0x0a, 0x0b, 0x68, 0x61,
+	0x73, 0x5f, 0x74, 0x78, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x09, 0x68, 0x61, 0x73,
+	0x54, 0x78, 0x48, 0x61, 0x73, 0x68, 0x12, 0x25, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x6f, 0x72, 0x74, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x61, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x61, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xbe, 0x01, 0x0a, 0x0a,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x67, 0x65, 0x12, 0x27, 0x0a, 0x07, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e,
+	0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0x1a, 0x0a, 0x08,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x21, 0x0a, 0x06, 0x54, 0x78, 0x48, 0x61,
+	0x73, 0x68, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x78, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x22, 0x4e, 0x0a, 0x0c, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x73, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x22, 0x84, 0x02, 0x0a, 0x0a,
+	0x54, 0x72, 0x61, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x72,
+	0x61, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x72, 0x61, 0x64,
+	0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x70, 0x72, 0x6f, 0x66,
+	0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65,
+	0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x61,
+	0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x12, 0x23, 0x0a, 0x0d,
+	0x6d, 0x65, 0x64, 0x69, 0x61, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x6e, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x67, 0x61, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03,
+	0x67, 0x61, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x77, 0x69, 0x6e, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x77, 0x69, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x62, 0x65, 0x73, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x65, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x12,
+	0x21, 0x0a, 0x0c, 0x77, 0x6f, 0x72, 0x73, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x77, 0x6f, 0x72, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x74, 0x22, 0x48, 0x0a, 0x09, 0x50, 0x61, 0x69, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70,
+	0x61, 0x69, 0x72, 0x12, 0x27, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x22, 0x64, 0x0a, 0x11,
+	0x50, 0x61, 0x69, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x26, 0x0a, 0x05, 0x70, 0x61, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x61, 0x69, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x05, 0x70, 0x61, 0x69, 0x72, 0x73, 0x12, 0x27, 0x0a, 0x05, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x54, 0x72, 0x61, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x22, 0x77, 0x0a, 0x0a, 0x50, 0x6e, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x69, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e,
+	0x74, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c,
+	0x12, 0x29, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x42, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x22, 0x74, 0x0a, 0x08, 0x50,
+	0x6e, 0x4c, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x75, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69,
+	0x76, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x10, 0x63, 0x75, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x74, 0x22, 0x36, 0x0a, 0x0b, 0x50, 0x6e, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x27, 0x0a, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x6e, 0x4c, 0x50, 0x6f, 0x69, 0x6e,
+	0x74, 0x52, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0xa5, 0x03, 0x0a, 0x06, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x67, 0x61, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x03, 0x67, 0x61, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x70, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x78, 0x5f, 0x68, 0x61, 0x73,
+	0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x12,
+	0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x73, 0x61, 0x6b, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x53, 0x61,
+	0x6b, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x73,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x55, 0x6e, 0x69,
+	0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x75, 0x6e, 0x69,
+	0x74, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x55, 0x6e, 0x69, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x19, 0x0a, 0x08, 0x67, 0x61, 0x73, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x67, 0x61, 0x73, 0x55, 0x73, 0x65, 0x64, 0x4a, 0x04, 0x08, 0x0b, 0x10,
+	0x0c, 0x52, 0x0e, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x5f, 0x64, 0x65, 0x63, 0x69, 0x6d, 0x61,
+	0x6c, 0x22, 0x64, 0x0a, 0x0b, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x12, 0x25, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x71, 0x0a, 0x05, 0x51, 0x75, 0x6f, 0x74, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x69, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x67, 0x61, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x67, 0x61, 0x73, 0x22, 0x60, 0x0a, 0x0a, 0x51, 0x75,
+	0x6f, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x22, 0x53, 0x0a, 0x0b,
+	0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6f,
+	0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6f, 0x6e, 0x6c,
+	0x69, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x22, 0x6e, 0x0a, 0x10, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68,
+	0x61, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x2a, 0x2b, 0x0a, 0x0a, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x6f, 0x72, 0x74, 0x12,
+	0x08, 0x0a, 0x04, 0x54, 0x49, 0x4d, 0x45, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x52, 0x4f,
+	0x46, 0x49, 0x54, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x41, 0x53, 0x10, 0x02, 0x2a, 0x29,
+	0x0a, 0x0a, 0x54, 0x69, 0x6d, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x07, 0x0a, 0x03,
+	0x44, 0x41, 0x59, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x4f, 0x55, 0x52, 0x10, 0x01, 0x12,
+	0x08, 0x0a, 0x04, 0x57, 0x45, 0x45, 0x4b, 0x10, 0x02, 0x32, 0x94, 0x01, 0x0a, 0x07, 0x50, 0x69,
+	0x6e, 0x67, 0x41, 0x50, 0x49, 0x12, 0x41, 0x0a, 0x04, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x22, 0x12, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x12, 0x0a, 0x2f, 0x70,
+	0x69, 0x6e, 0x67, 0x2f, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x46, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x22, 0x14, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x0e, 0x12, 0x0c, 0x2f, 0x70, 0x69, 0x6e, 0x67, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x32, 0xe8, 0x02, 0x0a, 0x08, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x41, 0x50, 0x49, 0x12, 0x52, 0x0a,
+	0x0c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x13, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x17, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x11, 0x12, 0x0f,
+	0x2f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x30,
+	0x01, 0x12, 0x4f, 0x0a, 0x0b, 0x57, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x73,
+	0x12, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x51, 0x75,
+	0x6f, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10,
+	0x12, 0x0e, 0x2f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x73,
+	0x30, 0x01, 0x12, 0x59, 0x0a, 0x10, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x68, 0x61, 0x69, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x12, 0x0d, 0x2f, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x30, 0x01, 0x12, 0x5c, 0x0a,
+	0x11, 0x57, 0x61, 0x74, 0x63, 0x68, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x1c, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x16, 0x12, 0x14, 0x2f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x30, 0x01, 0x32, 0xf1, 0x01, 0x0a, 0x0a,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x41, 0x50, 0x49, 0x12, 0x4d, 0x0a, 0x0b, 0x4c, 0x69,
+	0x73, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x50, 0x61, 0x67, 0x65, 0x22, 0x10, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0a, 0x12,
+	0x08, 0x2f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x42, 0x0a, 0x09, 0x47, 0x65, 0x74,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x1a, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x12, 0x0d,
+	0x2f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x50, 0x0a,
+	0x11, 0x47, 0x65, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x79, 0x54, 0x78, 0x48, 0x61,
+	0x73, 0x68, 0x12, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x78, 0x48, 0x61, 0x73,
+	0x68, 0x1a, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x12, 0x15, 0x2f, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x2f, 0x74, 0x78, 0x2f, 0x7b, 0x74, 0x78, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x7d, 0x32,
+	0xb3, 0x01, 0x0a, 0x0c, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x41, 0x50, 0x49,
+	0x12, 0x54, 0x0a, 0x09, 0x50, 0x61, 0x69, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x13, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x61, 0x69, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x18, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x12, 0x12, 0x10, 0x2f, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73,
+	0x2f, 0x70, 0x61, 0x69, 0x72, 0x73, 0x12, 0x4d, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x74,
+	0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50,
+	0x6e, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x50, 0x6e, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x16, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x10, 0x12, 0x0e, 0x2f, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63,
+	0x73, 0x2f, 0x70, 0x6e, 0x6c, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_types_proto_rawDescOnce sync.Once
+	file_types_proto_rawDescData = file_types_proto_rawDesc
+)
+
+func file_types_proto_rawDescGZIP() []byte {
+	file_types_proto_rawDescOnce.Do(func() {
+		file_types_proto_rawDescData = protoimpl.X.CompressGZIP(file_types_proto_rawDescData)
+	})
+	return file_types_proto_rawDescData
+}
+
+var file_types_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_types_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_types_proto_goTypes = []interface{}{
+	(ActionSort)(0),            // 0: proto.ActionSort
+	(TimeBucket)(0),            // 1: proto.TimeBucket
+	(*Boolean)(nil),            // 2: proto.Boolean
+	(*String)(nil),             // 3: proto.String
+	(*WatchRequest)(nil),       // 4: proto.WatchRequest
+	(*ListActionsRequest)(nil), // 5: proto.ListActionsRequest
+	(*ActionPage)(nil),         // 6: proto.ActionPage
+	(*ActionID)(nil),           // 7: proto.ActionID
+	(*TxHash)(nil),             // 8: proto.TxHash
+	(*StatsRequest)(nil),       // 9: proto.StatsRequest
+	(*TradeStats)(nil),         // 10: proto.TradeStats
+	(*PairStats)(nil),          // 11: proto.PairStats
+	(*PairStatsResponse)(nil),  // 12: proto.PairStatsResponse
+	(*PnLRequest)(nil),         // 13: proto.PnLRequest
+	(*PnLPoint)(nil),           // 14: proto.PnLPoint
+	(*PnLResponse)(nil),        // 15: proto.PnLResponse
+	(*Action)(nil),             // 16: proto.Action
+	(*ActionEvent)(nil),        // 17: proto.ActionEvent
+	(*Quote)(nil),              // 18: proto.Quote
+	(*QuoteEvent)(nil),         // 19: proto.QuoteEvent
+	(*ChainStatus)(nil),        // 20: proto.ChainStatus
+	(*ChainStatusEvent)(nil),   // 21: proto.ChainStatusEvent
+	(*wrappers.BoolValue)(nil), // 22: google.protobuf.BoolValue
+	(*empty.Empty)(nil),        // 23: google.protobuf.Empty
+}
+var file_types_proto_depIdxs = []int32{
+	22, // 0: proto.ListActionsRequest.include_sake:type_name -> google.protobuf.BoolValue
+	22, // 1: proto.ListActionsRequest.has_tx_hash:type_name -> google.protobuf.BoolValue
+	0,  // 2: proto.ListActionsRequest.sort:type_name -> proto.ActionSort
+	16, // 3: proto.ActionPage.actions:type_name -> proto.Action
+	10, // 4: proto.PairStats.stats:type_name -> proto.TradeStats
+	11, // 5: proto.PairStatsResponse.pairs:type_name -> proto.PairStats
+	10, // 6: proto.PairStatsResponse.total:type_name -> proto.TradeStats
+	1,  // 7: proto.PnLRequest.bucket:type_name -> proto.TimeBucket
+	10, // 8: proto.PnLPoint.stats:type_name -> proto.TradeStats
+	14, // 9: proto.PnLResponse.points:type_name -> proto.PnLPoint
+	16, // 10: proto.ActionEvent.action:type_name -> proto.Action
+	18, // 11: proto.QuoteEvent.quote:type_name -> proto.Quote
+	20, // 12: proto.ChainStatusEvent.status:type_name -> proto.ChainStatus
+	23, // 13: proto.PingAPI.Info:input_type -> google.protobuf.Empty
+	23, // 14: proto.PingAPI.Status:input_type -> google.protobuf.Empty
+	4,  // 15: proto.EventAPI.WatchActions:input_type -> proto.WatchRequest
+	4,  // 16: proto.EventAPI.WatchQuotes:input_type -> proto.WatchRequest
+	4,  // 17: proto.EventAPI.WatchChainStatus:input_type -> proto.WatchRequest
+	4,  // 18: proto.EventAPI.WatchTransactions:input_type -> proto.WatchRequest
+	5,  // 19: proto.ActionsAPI.ListActions:input_type -> proto.ListActionsRequest
+	7,  // 20: proto.ActionsAPI.GetAction:input_type -> proto.ActionID
+	8,  // 21: proto.ActionsAPI.GetActionByTxHash:input_type -> proto.TxHash
+	9,  // 22: proto.AnalyticsAPI.PairStats:input_type -> proto.StatsRequest
+	13, // 23: proto.AnalyticsAPI.ProfitSeries:input_type -> proto.PnLRequest
+	3,  // 24: proto.PingAPI.Info:output_type -> proto.String
+	2,  // 25: proto.PingAPI.Status:output_type -> proto.Boolean
+	17, // 26: proto.EventAPI.WatchActions:output_type -> proto.ActionEvent
+	19, // 27: proto.EventAPI.WatchQuotes:output_type -> proto.QuoteEvent
+	21, // 28: proto.EventAPI.WatchChainStatus:output_type -> proto.ChainStatusEvent
+	17, // 29: proto.EventAPI.WatchTransactions:output_type -> proto.ActionEvent
+	6,  // 30: proto.ActionsAPI.ListActions:output_type -> proto.ActionPage
+	16, // 31: proto.ActionsAPI.GetAction:output_type -> proto.Action
+	16, // 32: proto.ActionsAPI.GetActionByTxHash:output_type -> proto.Action
+	12, // 33: proto.AnalyticsAPI.PairStats:output_type -> proto.PairStatsResponse
+	15, // 34: proto.AnalyticsAPI.ProfitSeries:output_type -> proto.PnLResponse
+	24, // [24:35] is the sub-list for method output_type
+	13, // [13:24] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_types_proto_init() }
+func file_types_proto_init() {
+	if File_types_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_types_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Boolean); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*String); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListActionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionPage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxHash); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TradeStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PairStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PairStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PnLRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PnLPoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PnLResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Action); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Quote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuoteEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChainStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChainStatusEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_types_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   4,
+		},
+		GoTypes:           file_types_proto_goTypes,
+		DependencyIndexes: file_types_proto_depIdxs,
+		EnumInfos:         file_types_proto_enumTypes,
+		MessageInfos:      file_types_proto_msgTypes,
+	}.Build()
+	File_types_proto = out.File
+	file_types_proto_rawDesc = nil
+	file_types_proto_goTypes = nil
+	file_types_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// PingAPIClient is the client API for PingAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PingAPIClient interface {
+	Info(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*String, error)
+	Status(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Boolean, error)
+}
+
+type pingAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPingAPIClient(cc grpc.ClientConnInterface) PingAPIClient {
+	return &pingAPIClient{cc}
+}
+
+func (c *pingAPIClient) Info(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*String, error) {
+	out := new(String)
+	err := c.cc.Invoke(ctx, "/proto.PingAPI/Info", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pingAPIClient) Status(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Boolean, error) {
+	out := new(Boolean)
+	err := c.cc.Invoke(ctx, "/proto.PingAPI/Status", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PingAPIServer is the server API for PingAPI service.
+type PingAPIServer interface {
+	Info(context.Context, *empty.Empty) (*String, error)
+	Status(context.Context, *empty.Empty) (*Boolean, error)
+}
+
+// UnimplementedPingAPIServer can be embedded to have forward compatible implementations.
+type UnimplementedPingAPIServer struct {
+}
+
+func (*UnimplementedPingAPIServer) Info(context.Context, *empty.Empty) (*String, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Info not implemented")
+}
+func (*UnimplementedPingAPIServer) Status(context.Context, *empty.Empty) (*Boolean, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+
+func RegisterPingAPIServer(s *grpc.Server, srv PingAPIServer) {
+	s.RegisterService(&_PingAPI_serviceDesc, srv)
+}
+
+func _PingAPI_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PingAPIServer).Info(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.PingAPI/Info",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PingAPIServer).Info(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PingAPI_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PingAPIServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.PingAPI/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PingAPIServer).Status(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PingAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.PingAPI",
+	HandlerType: (*PingAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Info",
+			Handler:    _PingAPI_Info_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _PingAPI_Status_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "types.proto",
+}
+
+// EventAPIClient is the client API for EventAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type EventAPIClient interface {
+	WatchActions(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (EventAPI_WatchActionsClient, error)
+	// WatchQuotes sends the quote each recorded swap was executed at.
+	WatchQuotes(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (EventAPI_WatchQuotesClient, error)
+	WatchChainStatus(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (EventAPI_WatchChainStatusClient, error)
+	// WatchTransactions sends the actions whose transaction confirmed, failed or was
+	// dropped.
+	WatchTransactions(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (EventAPI_WatchTransactionsClient, error)
+}
+
+type eventAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEventAPIClient(cc grpc.ClientConnInterface) EventAPIClient {
+	return &eventAPIClient{cc}
+}
+
+func (c *eventAPIClient) WatchActions(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (EventAPI_WatchActionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_EventAPI_serviceDesc.Streams[0], "/proto.EventAPI/WatchActions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &eventAPIWatchActionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EventAPI_WatchActionsClient interface {
+	Recv() (*ActionEvent, error)
+	grpc.ClientStream
+}
+
+type eventAPIWatchActionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventAPIWatchActionsClient) Recv() (*ActionEvent, error) {
+	m := new(ActionEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *eventAPIClient) WatchQuotes(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (EventAPI_WatchQuotesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_EventAPI_serviceDesc.Streams[1], "/proto.EventAPI/WatchQuotes", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &eventAPIWatchQuotesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EventAPI_WatchQuotesClient interface {
+	Recv() (*QuoteEvent, error)
+	grpc.ClientStream
+}
+
+type eventAPIWatchQuotesClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventAPIWatchQuotesClient) Recv() (*QuoteEvent, error) {
+	m := new(QuoteEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *eventAPIClient) WatchChainStatus(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (EventAPI_WatchChainStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_EventAPI_serviceDesc.Streams[2], "/proto.EventAPI/WatchChainStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &eventAPIWatchChainStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EventAPI_WatchChainStatusClient interface {
+	Recv() (*ChainStatusEvent, error)
+	grpc.ClientStream
+}
+
+type eventAPIWatchChainStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventAPIWatchChainStatusClient) Recv() (*ChainStatusEvent, error) {
+	m := new(ChainStatusEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *eventAPIClient) WatchTransactions(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (EventAPI_WatchTransactionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_EventAPI_serviceDesc.Streams[3], "/proto.EventAPI/WatchTransactions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &eventAPIWatchTransactionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EventAPI_WatchTransactionsClient interface {
+	Recv() (*ActionEvent, error)
+	grpc.ClientStream
+}
+
+type eventAPIWatchTransactionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventAPIWatchTransactionsClient) Recv() (*ActionEvent, error) {
+	m := new(ActionEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// EventAPIServer is the server API for EventAPI service.
+type EventAPIServer interface {
+	WatchActions(*WatchRequest, EventAPI_WatchActionsServer) error
+	// WatchQuotes sends the quote each recorded swap was executed at.
+	WatchQuotes(*WatchRequest, EventAPI_WatchQuotesServer) error
+	WatchChainStatus(*WatchRequest, EventAPI_WatchChainStatusServer) error
+	// WatchTransactions sends the actions whose transaction confirmed, failed or was
+	// dropped.
+	WatchTransactions(*WatchRequest, EventAPI_WatchTransactionsServer) error
+}
+
+// UnimplementedEventAPIServer can be embedded to have forward compatible implementations.
+type UnimplementedEventAPIServer struct {
+}
+
+func (*UnimplementedEventAPIServer) WatchActions(*WatchRequest, EventAPI_WatchActionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchActions not implemented")
+}
+func (*UnimplementedEventAPIServer) WatchQuotes(*WatchRequest, EventAPI_WatchQuotesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchQuotes not implemented")
+}
+func (*UnimplementedEventAPIServer) WatchChainStatus(*WatchRequest, EventAPI_WatchChainStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchChainStatus not implemented")
+}
+func (*UnimplementedEventAPIServer) WatchTransactions(*WatchRequest, EventAPI_WatchTransactionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchTransactions not implemented")
+}
+
+func RegisterEventAPIServer(s *grpc.Server, srv EventAPIServer) {
+	s.RegisterService(&_EventAPI_serviceDesc, srv)
+}
+
+func _EventAPI_WatchActions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventAPIServer).WatchActions(m, &eventAPIWatchActionsServer{stream})
+}
+
+type EventAPI_WatchActionsServer interface {
+	Send(*ActionEvent) error
+	grpc.ServerStream
+}
+
+type eventAPIWatchActionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *eventAPIWatchActionsServer) Send(m *ActionEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _EventAPI_WatchQuotes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventAPIServer).WatchQuotes(m, &eventAPIWatchQuotesServer{stream})
+}
+
+type EventAPI_WatchQuotesServer interface {
+	Send(*QuoteEvent) error
+	grpc.ServerStream
+}
+
+type eventAPIWatchQuotesServer struct {
+	grpc.ServerStream
+}
+
+func (x *eventAPIWatchQuotesServer) Send(m *QuoteEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _EventAPI_WatchChainStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventAPIServer).WatchChainStatus(m, &eventAPIWatchChainStatusServer{stream})
+}
+
+type EventAPI_WatchChainStatusServer interface {
+	Send(*ChainStatusEvent) error
+	grpc.ServerStream
+}
+
+type eventAPIWatchChainStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *eventAPIWatchChainStatusServer) Send(m *ChainStatusEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _EventAPI_WatchTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventAPIServer).WatchTransactions(m, &eventAPIWatchTransactionsServer{stream})
+}
+
+type EventAPI_WatchTransactionsServer interface {
+	Send(*ActionEvent) error
+	grpc.ServerStream
+}
+
+type eventAPIWatchTransactionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *eventAPIWatchTransactionsServer) Send(m *ActionEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _EventAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.EventAPI",
+	HandlerType: (*EventAPIServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchActions",
+			Handler:       _EventAPI_WatchActions_Handler,
+			ServerStreams: true,
 		},
 		{
-			MethodName: "Status",
-			Handler:    _PingAPI_Status_Handler,
+			StreamName:    "WatchQuotes",
+			Handler:       _EventAPI_WatchQuotes_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchChainStatus",
+			Handler:       _EventAPI_WatchChainStatus_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchTransactions",
+			Handler:       _EventAPI_WatchTransactions_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "types.proto",
+}
+
+// ActionsAPIClient is the client API for ActionsAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ActionsAPIClient interface {
+	ListActions(ctx context.Context, in *ListActionsRequest, opts ...grpc.CallOption) (*ActionPage, error)
+	GetAction(ctx context.Context, in *ActionID, opts ...grpc.CallOption) (*Action, error)
+	GetActionByTxHash(ctx context.Context, in *TxHash, opts ...grpc.CallOption) (*Action, error)
+}
+
+type actionsAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewActionsAPIClient(cc grpc.ClientConnInterface) ActionsAPIClient {
+	return &actionsAPIClient{cc}
+}
+
+func (c *actionsAPIClient) ListActions(ctx context.Context, in *ListActionsRequest, opts ...grpc.CallOption) (*ActionPage, error) {
+	out := new(ActionPage)
+	err := c.cc.Invoke(ctx, "/proto.ActionsAPI/ListActions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *actionsAPIClient) GetAction(ctx context.Context, in *ActionID, opts ...grpc.CallOption) (*Action, error) {
+	out := new(Action)
+	err := c.cc.Invoke(ctx, "/proto.ActionsAPI/GetAction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *actionsAPIClient) GetActionByTxHash(ctx context.Context, in *TxHash, opts ...grpc.CallOption) (*Action, error) {
+	out := new(Action)
+	err := c.cc.Invoke(ctx, "/proto.ActionsAPI/GetActionByTxHash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ActionsAPIServer is the server API for ActionsAPI service.
+type ActionsAPIServer interface {
+	ListActions(context.Context, *ListActionsRequest) (*ActionPage, error)
+	GetAction(context.Context, *ActionID) (*Action, error)
+	GetActionByTxHash(context.Context, *TxHash) (*Action, error)
+}
+
+// UnimplementedActionsAPIServer can be embedded to have forward compatible implementations.
+type UnimplementedActionsAPIServer struct {
+}
+
+func (*UnimplementedActionsAPIServer) ListActions(context.Context, *ListActionsRequest) (*ActionPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListActions not implemented")
+}
+func (*UnimplementedActionsAPIServer) GetAction(context.Context, *ActionID) (*Action, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAction not implemented")
+}
+func (*UnimplementedActionsAPIServer) GetActionByTxHash(context.Context, *TxHash) (*Action, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActionByTxHash not implemented")
+}
+
+func RegisterActionsAPIServer(s *grpc.Server, srv ActionsAPIServer) {
+	s.RegisterService(&_ActionsAPI_serviceDesc, srv)
+}
+
+func _ActionsAPI_ListActions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListActionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActionsAPIServer).ListActions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ActionsAPI/ListActions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActionsAPIServer).ListActions(ctx, req.(*ListActionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ActionsAPI_GetAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActionID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActionsAPIServer).GetAction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ActionsAPI/GetAction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActionsAPIServer).GetAction(ctx, req.(*ActionID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ActionsAPI_GetActionByTxHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxHash)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActionsAPIServer).GetActionByTxHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ActionsAPI/GetActionByTxHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActionsAPIServer).GetActionByTxHash(ctx, req.(*TxHash))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ActionsAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.ActionsAPI",
+	HandlerType: (*ActionsAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListActions",
+			Handler:    _ActionsAPI_ListActions_Handler,
+		},
+		{
+			MethodName: "GetAction",
+			Handler:    _ActionsAPI_GetAction_Handler,
+		},
+		{
+			MethodName: "GetActionByTxHash",
+			Handler:    _ActionsAPI_GetActionByTxHash_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "types.proto",
+}
+
+// AnalyticsAPIClient is the client API for AnalyticsAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AnalyticsAPIClient interface {
+	PairStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*PairStatsResponse, error)
+	ProfitSeries(ctx context.Context, in *PnLRequest, opts ...grpc.CallOption) (*PnLResponse, error)
+}
+
+type analyticsAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAnalyticsAPIClient(cc grpc.ClientConnInterface) AnalyticsAPIClient {
+	return &analyticsAPIClient{cc}
+}
+
+func (c *analyticsAPIClient) PairStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*PairStatsResponse, error) {
+	out := new(PairStatsResponse)
+	err := c.cc.Invoke(ctx, "/proto.AnalyticsAPI/PairStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsAPIClient) ProfitSeries(ctx context.Context, in *PnLRequest, opts ...grpc.CallOption) (*PnLResponse, error) {
+	out := new(PnLResponse)
+	err := c.cc.Invoke(ctx, "/proto.AnalyticsAPI/ProfitSeries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnalyticsAPIServer is the server API for AnalyticsAPI service.
+type AnalyticsAPIServer interface {
+	PairStats(context.Context, *StatsRequest) (*PairStatsResponse, error)
+	ProfitSeries(context.Context, *PnLRequest) (*PnLResponse, error)
+}
+
+// UnimplementedAnalyticsAPIServer can be embedded to have forward compatible implementations.
+type UnimplementedAnalyticsAPIServer struct {
+}
+
+func (*UnimplementedAnalyticsAPIServer) PairStats(context.Context, *StatsRequest) (*PairStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PairStats not implemented")
+}
+func (*UnimplementedAnalyticsAPIServer) ProfitSeries(context.Context, *PnLRequest) (*PnLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProfitSeries not implemented")
+}
+
+func RegisterAnalyticsAPIServer(s *grpc.Server, srv AnalyticsAPIServer) {
+	s.RegisterService(&_AnalyticsAPI_serviceDesc, srv)
+}
+
+func _AnalyticsAPI_PairStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsAPIServer).PairStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.AnalyticsAPI/PairStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsAPIServer).PairStats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsAPI_ProfitSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PnLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsAPIServer).ProfitSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.AnalyticsAPI/ProfitSeries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsAPIServer).ProfitSeries(ctx, req.(*PnLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AnalyticsAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.AnalyticsAPI",
+	HandlerType: (*AnalyticsAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PairStats",
+			Handler:    _AnalyticsAPI_PairStats_Handler,
+		},
+		{
+			MethodName: "ProfitSeries",
+			Handler:    _AnalyticsAPI_ProfitSeries_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
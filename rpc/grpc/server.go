@@ -4,21 +4,40 @@ import (
 	"context"
 	"fmt"
 	"github.com/drip/beyond/config"
+	"github.com/drip/beyond/pkg/db"
+	"github.com/drip/beyond/pkg/health"
 	"github.com/drip/beyond/pkg/log"
 	"github.com/drip/beyond/pkg/util"
 	"github.com/drip/beyond/rpc/grpc/apis"
 	pb "github.com/drip/beyond/rpc/grpc/proto"
+	"github.com/drip/beyond/rpc/grpc/swagger"
+	"github.com/golang/protobuf/proto"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
 	"net"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/rs/cors"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
+// inprocBufferSize is the buffer of in-process connections, messages are not limited by it.
+const inprocBufferSize = 1 << 20
+
+// swaggerPath serves the API explorer and the OpenAPI document of the gateway.
+const swaggerPath = "/swagger/"
+
+// exportPath serves downloads of the action history.
+const exportPath = "/export/actions"
+
 type Server struct {
 	rpc    *grpc.Server
 	srv    *http.Server
@@ -26,88 +45,210 @@ type Server struct {
 	cancel context.CancelFunc
 	cfg    *config.Config
 	logger *zap.SugaredLogger
+	mounts map[string]http.Handler
+	probes map[string]http.Handler
+
+	// serves the gateway and the JSON-RPC bridge without a network hop
+	inproc *bufconn.Listener
+
+	bridgeMu sync.Mutex
+	bridge   *grpc.ClientConn
+
+	events  *apis.EventApi
+	actions *apis.ActionsApi
+	health  *grpchealth.Server
 }
 
-func NewServer(cfg *config.Config) *Server {
-	gRpcServer := grpc.NewServer(grpc.StreamInterceptor(streamServerInterceptor),
-		grpc.UnaryInterceptor(unaryServerInterceptor))
+// NewServer creates the gRPC server, the actions service reads from database if it is
+// not nil.
+func NewServer(cfg *config.Config, database *gorm.DB) (*Server, error) {
+	logger := log.NewLogger("rpc")
+	def, max, methods, err := cfg.GRPCCfg.Deadlines.Durations()
+	if err != nil {
+		return nil, fmt.Errorf("deadlines: %s", err)
+	}
+	i := &interceptors{deadlines: &deadlines{def: def, max: max, methods: methods}, logger: logger}
+	opts, err := serverOptions(cfg.GRPCCfg.Server)
+	if err != nil {
+		return nil, fmt.Errorf("server options: %s", err)
+	}
+	gRpcServer := grpc.NewServer(append(opts, grpc.StreamInterceptor(i.stream),
+		grpc.UnaryInterceptor(i.unary))...)
 
 	ctx, cancel := context.WithCancel(context.Background())
 
-	return &Server{
+	g := &Server{
 		cfg:    cfg,
 		rpc:    gRpcServer,
 		ctx:    ctx,
 		cancel: cancel,
-		logger: log.NewLogger("rpc"),
+		logger: logger,
+		mounts: make(map[string]http.Handler),
+		probes: make(map[string]http.Handler),
+		inproc: bufconn.Listen(inprocBufferSize),
+	}
+	// Register the services up front, they are bridged to JSON-RPC before Start
+	if err := g.registerApi(database); err != nil {
+		cancel()
+		return nil, fmt.Errorf("registerApi: %s", err)
+	}
+	reflection.Register(g.rpc)
+	g.health = grpchealth.NewServer()
+	healthpb.RegisterHealthServer(g.rpc, g.health)
+	return g, nil
+}
+
+// SetHealth reports the status of the monitored components through the grpc.health.v1
+// service. Components are checked by their name, the registered services and the empty
+// service name by the readiness of all components.
+func (g *Server) SetHealth(m *health.Monitor) {
+	m.OnChange(g.updateHealth)
+	g.updateHealth(m.Report())
+}
+
+func (g *Server) updateHealth(r health.Report) {
+	for _, c := range r.Components {
+		g.health.SetServingStatus(c.Name, servingStatus(c.Status == health.StatusUp))
+	}
+	ready := servingStatus(r.Ready)
+	g.health.SetServingStatus("", ready)
+	for name := range g.rpc.GetServiceInfo() {
+		g.health.SetServingStatus(name, ready)
+	}
+}
+
+func servingStatus(up bool) healthpb.HealthCheckResponse_ServingStatus {
+	if up {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+// Endpoints returns the listen addresses of the enabled gRPC server and gateway by name.
+func (g *Server) Endpoints() map[string]string {
+	endpoints := make(map[string]string)
+	if !g.cfg.GRPCCfg.GRPCDisabled {
+		endpoints["grpc"] = g.cfg.GRPCCfg.GRPCListenAddress
+	}
+	if !g.cfg.GRPCCfg.GatewayDisabled {
+		endpoints["gateway"] = g.cfg.GRPCCfg.ListenAddress
 	}
+	return endpoints
+}
+
+// Handle serves handler on the gateway for path and all paths below it, next to the
+// RESTful APIs. It must be called before Start.
+func (g *Server) Handle(path string, handler http.Handler) {
+	g.mounts[strings.TrimSuffix(path, "/")] = handler
+}
+
+// HandleProbe serves a health probe on the gateway address for path. Unlike handlers
+// added by Handle, probes are served with the gateway disabled too. It must be called
+// before Start.
+func (g *Server) HandleProbe(path string, handler http.Handler) {
+	g.probes[path] = handler
 }
 
+// Start serves the gRPC services in process and on the enabled listeners. The gateway
+// calls the services in process, it works with the gRPC listener disabled. The gateway
+// address is always listened on, with the gateway disabled it serves the probes only.
 func (g *Server) Start() error {
-	network, address, err := util.Scheme(g.cfg.GRPCCfg.GRPCListenAddress)
+	sockOpts, err := g.cfg.GRPCCfg.Socket.Options()
 	if err != nil {
 		return err
 	}
-
-	lis, err := net.Listen(network, address)
+	var lis net.Listener
+	if !g.cfg.GRPCCfg.GRPCDisabled && !g.multiplexed() {
+		if lis, err = util.Listen(g.cfg.GRPCCfg.GRPCListenAddress, sockOpts); err != nil {
+			return fmt.Errorf("failed to listen: %s", err)
+		}
+	}
+	gwLis, err := util.Listen(g.cfg.GRPCCfg.ListenAddress, sockOpts)
 	if err != nil {
+		if lis != nil {
+			lis.Close()
+		}
 		return fmt.Errorf("failed to listen: %s", err)
 	}
-	if err := g.registerApi(); err != nil {
-		g.logger.Error(err)
-		return fmt.Errorf("registerApi: %s", err)
-	}
-	reflection.Register(g.rpc)
-	go func() {
-		if err := g.rpc.Serve(lis); err != nil {
-			g.logger.Error(err)
+	for _, l := range []net.Listener{g.inproc, lis} {
+		if l == nil {
+			continue
 		}
-	}()
+		go func(l net.Listener) {
+			if err := g.rpc.Serve(l); err != nil {
+				g.logger.Error(err)
+			}
+		}(l)
+	}
+	if g.srv, err = g.newGateway(); err != nil {
+		gwLis.Close()
+		return err
+	}
+	if g.multiplexed() {
+		g.srv.Handler = newMultiplexHandler(g.rpc, g.srv.Handler)
+		g.logger.Infof("gateway serves gRPC on %s", g.cfg.GRPCCfg.ListenAddress)
+	}
 	go func() {
-		if err := g.newGateway(address, g.cfg.GRPCCfg.ListenAddress); err != nil {
+		if err := g.srv.Serve(gwLis); err != http.ErrServerClosed {
 			g.logger.Errorf("gateway listen err: %s", err)
 		}
 	}()
 
+	g.events.Start()
 	g.logger.Info("rpc server started")
 
 	return nil
 }
 
-func (g *Server) newGateway(grpcAddress, gwAddress string) error {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+// multiplexed reports whether gRPC shares the listener of the gateway address, which is
+// the case if it is enabled on the same address.
+func (g *Server) multiplexed() bool {
+	c := g.cfg.GRPCCfg
+	return !c.GRPCDisabled && c.GRPCListenAddress == c.ListenAddress
+}
 
-	gwmux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{OrigName: true, EmitDefaults: true}))
-	// no need proxy for internal gateway to internal rpc server
-	optDial := grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
-		network := "tcp"
-		g.logger.Debugf("WithContextDialer addr %s", addr)
-		return (&net.Dialer{}).DialContext(ctx, network, addr)
-	})
-	opts := []grpc.DialOption{grpc.WithInsecure(), optDial}
-	if err := registerGWApi(ctx, gwmux, grpcAddress, opts); err != nil {
-		return fmt.Errorf("gateway register: %s", err)
+// newGateway returns the server of the RESTful gateway, the mounted handlers and the
+// probes. With the gateway disabled, it serves the probes only.
+func (g *Server) newGateway() (*http.Server, error) {
+	mux := http.NewServeMux()
+	for path, h := range g.probes {
+		mux.Handle(path, h)
 	}
-	_, address, err := util.Scheme(gwAddress)
-	if err != nil {
-		return err
+	if g.cfg.GRPCCfg.GatewayDisabled {
+		return &http.Server{Handler: mux}, nil
 	}
-	handler := newCorsHandler(gwmux, g.cfg.GRPCCfg.CORSAllowedOrigins)
 
-	g.srv = &http.Server{
-		Addr:    address,
-		Handler: handler,
+	gwmux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{OrigName: true, EmitDefaults: true}),
+		runtime.WithForwardResponseOption(streamHeaders),
+		runtime.WithIncomingHeaderMatcher(incomingHeader),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeader))
+	if err := registerGWApi(g.ctx, gwmux, "inproc", g.dialOptions()); err != nil {
+		return nil, fmt.Errorf("gateway register: %s", err)
+	}
+	mux.Handle("/", newCorsHandler(gwmux, g.cfg.GRPCCfg.CORSAllowedOrigins))
+	mux.Handle(swaggerPath, swagger.Handler(swaggerPath))
+	for path, h := range g.mounts {
+		mux.Handle(path, h)
+		mux.Handle(path+"/", h)
+		g.logger.Infof("gateway serves %s", path)
 	}
 
-	g.srv.RegisterOnShutdown(func() {
+	srv := &http.Server{
+		Handler: newGRPCWebHandler(g.rpc, mux, g.cfg.GRPCCfg.CORSAllowedOrigins),
+	}
+	srv.RegisterOnShutdown(func() {
 		g.logger.Debug("RESEful server shutdown")
 	})
+	return srv, nil
+}
 
-	if err := g.srv.ListenAndServe(); err != http.ErrServerClosed {
-		return err
-	}
-	return nil
+// dialOptions returns the options to connect to the in-process listener.
+func (g *Server) dialOptions() []grpc.DialOption {
+	optDial := grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+		return g.inproc.Dial()
+	})
+	opts := []grpc.DialOption{grpc.WithInsecure(), optDial}
+	return append(opts, clientOptions(g.cfg.GRPCCfg.Server)...)
 }
 
 func (g *Server) Stop() {
@@ -116,24 +257,104 @@ func (g *Server) Stop() {
 		cancel()
 	}()
 
+	// end the event streams first, the gateway waits for them otherwise
+	g.health.Shutdown()
+	g.events.Stop()
 	if g.srv != nil {
 		if err := g.srv.Shutdown(ctx); err != nil {
 			g.logger.Errorf("RESTful server shutdown failed:%+v", err)
 		}
 	}
+	// closes the connections of the gateway
+	g.cancel()
+	g.bridgeMu.Lock()
+	if g.bridge != nil {
+		g.bridge.Close()
+		g.bridge = nil
+	}
+	g.bridgeMu.Unlock()
 	g.rpc.Stop()
 	g.logger.Info("rpc stopped")
 }
 
-func (g *Server) registerApi() error {
+func (g *Server) registerApi(database *gorm.DB) error {
 	pb.RegisterPingAPIServer(g.rpc, apis.NewPingApi(g.cfg))
+	events, err := apis.NewEventApi(g.cfg)
+	if err != nil {
+		return err
+	}
+	g.events = events
+	pb.RegisterEventAPIServer(g.rpc, g.events)
+	if database != nil {
+		g.actions = apis.NewActionsApi(database, g.events)
+		pb.RegisterActionsAPIServer(g.rpc, g.actions)
+		pb.RegisterAnalyticsAPIServer(g.rpc, apis.NewAnalyticsApi(database))
+		g.Handle(exportPath, apis.NewExportHandler(database))
+	}
 	return nil
 }
 
+// Events returns the API streaming trading events, producers publish through it.
+func (g *Server) Events() *apis.EventApi {
+	return g.events
+}
+
+// PublishAction streams a committed action and its quote to the WatchActions and
+// WatchQuotes subscribers.
+func (g *Server) PublishAction(action *db.InchAction) {
+	if g.actions != nil {
+		g.actions.PublishAction(action)
+	}
+}
+
+// PublishTransaction streams an action whose transaction is final to the
+// WatchTransactions subscribers.
+func (g *Server) PublishTransaction(action *db.InchAction) {
+	if g.actions != nil {
+		g.actions.PublishTransaction(action)
+	}
+}
+
 func registerGWApi(ctx context.Context, gwmux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) error {
 	if err := pb.RegisterPingAPIHandlerFromEndpoint(ctx, gwmux, endpoint, opts); err != nil {
 		return err
 	}
+	if err := pb.RegisterEventAPIHandlerFromEndpoint(ctx, gwmux, endpoint, opts); err != nil {
+		return err
+	}
+	if err := pb.RegisterActionsAPIHandlerFromEndpoint(ctx, gwmux, endpoint, opts); err != nil {
+		return err
+	}
+	if err := pb.RegisterAnalyticsAPIHandlerFromEndpoint(ctx, gwmux, endpoint, opts); err != nil {
+		return err
+	}
+	return nil
+}
+
+// incomingHeader passes the X-Request-Id header to the gRPC server.
+func incomingHeader(key string) (string, bool) {
+	if strings.EqualFold(key, RequestIDKey) {
+		return RequestIDKey, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeader returns the request ID as X-Request-Id header.
+func outgoingHeader(key string) (string, bool) {
+	if key == RequestIDKey {
+		return http.CanonicalHeaderKey(RequestIDKey), true
+	}
+	return fmt.Sprintf("%s%s", runtime.MetadataHeaderPrefix, key), true
+}
+
+// streamHeaders marks streaming responses as newline-delimited JSON. Streams are the
+// only responses forwarded without a message first.
+func streamHeaders(ctx context.Context, w http.ResponseWriter, resp proto.Message) error {
+	if resp == nil {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		// flush every event through proxies
+		w.Header().Set("X-Accel-Buffering", "no")
+	}
 	return nil
 }
 
@@ -149,14 +370,3 @@ func newCorsHandler(srv http.Handler, allowedOrigins []string) http.Handler {
 	})
 	return c.Handler(srv)
 }
-
-func unaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	resp, err := handler(ctx, req)
-	return resp, err
-}
-
-// StreamServerInterceptor is a gRPC server-side interceptor that provides Prometheus monitoring for Streaming RPCs.
-func streamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-	err := handler(srv, ss)
-	return err
-}
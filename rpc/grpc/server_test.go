@@ -0,0 +1,176 @@
+package grpc
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drip/beyond/config"
+	pb "github.com/drip/beyond/rpc/grpc/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+)
+
+func TestGatewayWithoutGRPCListener(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gateway")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	sock := filepath.Join(dir, "gateway.sock")
+
+	cfg := &config.Config{
+		Endpoint: "http://127.0.0.1:1",
+		GRPCCfg: &config.GRPCCfg{
+			ListenAddress:     "unix://" + sock,
+			GRPCListenAddress: "unix://" + filepath.Join(dir, "grpc.sock"),
+			GRPCDisabled:      true,
+		},
+	}
+	srv, err := NewServer(cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Stop()
+
+	if _, ok := srv.Endpoints()["grpc"]; ok {
+		t.Error("disabled grpc listener in endpoints")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "grpc.sock")); !os.IsNotExist(err) {
+		t.Errorf("grpc listener created: %v", err)
+	}
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", sock)
+		},
+	}}
+	resp, err := client.Get("http://gateway/ping/info")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get(RequestIDKey) == "" {
+		t.Errorf("status %d, headers %v: %s", resp.StatusCode, resp.Header, body)
+	}
+}
+
+func TestMultiplexedListener(t *testing.T) {
+	dir, err := ioutil.TempDir("", "multiplex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	sock := filepath.Join(dir, "api.sock")
+
+	cfg := &config.Config{
+		Endpoint: "http://127.0.0.1:1",
+		GRPCCfg: &config.GRPCCfg{
+			ListenAddress:     "unix://" + sock,
+			GRPCListenAddress: "unix://" + sock,
+		},
+	}
+	srv, err := NewServer(cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Stop()
+
+	dial := func(ctx context.Context, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, "unix", sock)
+	}
+	conn, err := grpc.Dial("multiplex", grpc.WithInsecure(), grpc.WithContextDialer(dial))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := pb.NewPingAPIClient(conn).Info(context.Background(), &empty.Empty{}); err != nil {
+		t.Fatalf("grpc call: %s", err)
+	}
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dial(ctx, "")
+		},
+	}}
+	resp, err := client.Get("http://gateway/ping/info")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("gateway status %d", resp.StatusCode)
+	}
+}
+
+func TestProbesWithoutGateway(t *testing.T) {
+	dir, err := ioutil.TempDir("", "probes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	sock := filepath.Join(dir, "gateway.sock")
+
+	cfg := &config.Config{
+		Endpoint: "http://127.0.0.1:1",
+		GRPCCfg: &config.GRPCCfg{
+			ListenAddress:     "unix://" + sock,
+			GRPCListenAddress: "unix://" + filepath.Join(dir, "grpc.sock"),
+			GatewayDisabled:   true,
+		},
+	}
+	srv, err := NewServer(cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	srv.HandleProbe("/healthz", ok)
+	srv.Handle("/mounted", ok)
+	if err := srv.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Stop()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", sock)
+		},
+	}}
+	for path, want := range map[string]int{
+		"/healthz":   http.StatusOK,
+		"/mounted":   http.StatusNotFound,
+		"/ping/info": http.StatusNotFound,
+	} {
+		resp, err := client.Get("http://gateway" + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("%s: status %d, want %d", path, resp.StatusCode, want)
+		}
+	}
+}
+
+func TestNewServerInvalidConfig(t *testing.T) {
+	for _, grpcCfg := range []*config.GRPCCfg{
+		{Deadlines: &config.DeadlineCfg{Default: "1m", Max: "10s"}},
+		{Server: &config.ServerCfg{ConnectionTimeout: "soon"}},
+		{Server: &config.ServerCfg{Keepalive: &config.KeepaliveCfg{Time: "-"}}},
+	} {
+		if srv, err := NewServer(&config.Config{GRPCCfg: grpcCfg}, nil); err == nil {
+			srv.Stop()
+			t.Errorf("%+v accepted", grpcCfg)
+		}
+	}
+}
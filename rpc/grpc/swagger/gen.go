@@ -0,0 +1,82 @@
+// +build ignore
+
+// gen embeds the OpenAPI document generated from the proto definitions into spec.go and
+// publishes it to the docs folder. It embeds the Swagger UI files of the ui folder into
+// assets.go. Run it with go generate after regenerating the protos.
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+)
+
+const (
+	source = "../proto/types.swagger.json"
+	docs   = "../../../docs/swagger.json"
+	ui     = "ui"
+)
+
+// assets are the files of the ui folder served by the API explorer.
+var assets = []string{"swagger-ui-bundle.js", "swagger-ui.css"}
+
+func main() {
+	spec, err := ioutil.ReadFile(source)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if bytes.ContainsRune(spec, '`') {
+		log.Fatalf("%s contains a backquote", source)
+	}
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by gen.go from", source+". DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package swagger")
+	fmt.Fprintln(&buf)
+	fmt.Fprintf(&buf, "const spec = `%s`\n", spec)
+	if err := ioutil.WriteFile("spec.go", buf.Bytes(), 0644); err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(docs, spec, 0644); err != nil {
+		log.Fatal(err)
+	}
+
+	buf.Reset()
+	fmt.Fprintln(&buf, "// Code generated by gen.go from", ui+". DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package swagger")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "// assets are the gzipped Swagger UI files by name, base64 encoded.")
+	fmt.Fprintln(&buf, "var assets = map[string]string{")
+	for _, name := range assets {
+		data, err := ioutil.ReadFile(filepath.Join(ui, name))
+		if err != nil {
+			log.Fatal(err)
+		}
+		var gz bytes.Buffer
+		w, err := gzip.NewWriterLevel(&gz, gzip.BestCompression)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if _, err := w.Write(data); err != nil {
+			log.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Fprintf(&buf, "\t%q: %q,\n", name, base64.StdEncoding.EncodeToString(gz.Bytes()))
+	}
+	fmt.Fprintln(&buf, "}")
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile("assets.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
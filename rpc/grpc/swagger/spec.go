@@ -0,0 +1,878 @@
+// Code generated by gen.go from ../proto/types.swagger.json. DO NOT EDIT.
+
+package swagger
+
+const spec = `{
+  "swagger": "2.0",
+  "info": {
+    "title": "types.proto",
+    "version": "version not set"
+  },
+  "consumes": [
+    "application/json"
+  ],
+  "produces": [
+    "application/json"
+  ],
+  "paths": {
+    "/actions": {
+      "get": {
+        "operationId": "ActionsAPI_ListActions",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/protoActionPage"
+            }
+          },
+          "default": {
+            "description": "An unexpected error response",
+            "schema": {
+              "$ref": "#/definitions/runtimeError"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "page",
+            "description": "starts at 1, 0 selects the first page.",
+            "in": "query",
+            "required": false,
+            "type": "integer",
+            "format": "int32"
+          },
+          {
+            "name": "page_size",
+            "description": "10 by default, at most 100.",
+            "in": "query",
+            "required": false,
+            "type": "integer",
+            "format": "int32"
+          },
+          {
+            "name": "pair",
+            "description": "filters, unset ones match all actions.",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          },
+          {
+            "name": "include_sake",
+            "in": "query",
+            "required": false,
+            "type": "boolean"
+          },
+          {
+            "name": "since",
+            "description": "created at or after since and before until, unix milliseconds.",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "format": "int64"
+          },
+          {
+            "name": "until",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "format": "int64"
+          },
+          {
+            "name": "min_profit",
+            "description": "profit within [min_profit, max_profit], exact decimals.",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          },
+          {
+            "name": "max_profit",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          },
+          {
+            "name": "has_tx_hash",
+            "in": "query",
+            "required": false,
+            "type": "boolean"
+          },
+          {
+            "name": "sort",
+            "description": "newest actions first by default.",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "enum": [
+              "TIME",
+              "PROFIT",
+              "GAS"
+            ],
+            "default": "TIME"
+          },
+          {
+            "name": "ascending",
+            "in": "query",
+            "required": false,
+            "type": "boolean",
+            "format": "boolean"
+          },
+          {
+            "name": "cursor",
+            "description": "next_cursor of the previous page, page is ignored if set.",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          },
+          {
+            "name": "status",
+            "description": "status of the transaction: pending, confirmed, failed or dropped, unknown for\nswaps recorded before transactions were tracked.",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "ActionsAPI"
+        ]
+      }
+    },
+    "/actions/tx/{tx_hash}": {
+      "get": {
+        "operationId": "ActionsAPI_GetActionByTxHash",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/protoAction"
+            }
+          },
+          "default": {
+            "description": "An unexpected error response",
+            "schema": {
+              "$ref": "#/definitions/runtimeError"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "tx_hash",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "ActionsAPI"
+        ]
+      }
+    },
+    "/actions/{id}": {
+      "get": {
+        "operationId": "ActionsAPI_GetAction",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/protoAction"
+            }
+          },
+          "default": {
+            "description": "An unexpected error response",
+            "schema": {
+              "$ref": "#/definitions/runtimeError"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "id",
+            "in": "path",
+            "required": true,
+            "type": "string",
+            "format": "uint64"
+          }
+        ],
+        "tags": [
+          "ActionsAPI"
+        ]
+      }
+    },
+    "/analytics/pairs": {
+      "get": {
+        "operationId": "AnalyticsAPI_PairStats",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/protoPairStatsResponse"
+            }
+          },
+          "default": {
+            "description": "An unexpected error response",
+            "schema": {
+              "$ref": "#/definitions/runtimeError"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "pair",
+            "description": "all pairs if empty.",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          },
+          {
+            "name": "since",
+            "description": "created at or after since and before until, unix milliseconds.",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "format": "int64"
+          },
+          {
+            "name": "until",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "format": "int64"
+          }
+        ],
+        "tags": [
+          "AnalyticsAPI"
+        ]
+      }
+    },
+    "/analytics/pnl": {
+      "get": {
+        "operationId": "AnalyticsAPI_ProfitSeries",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/protoPnLResponse"
+            }
+          },
+          "default": {
+            "description": "An unexpected error response",
+            "schema": {
+              "$ref": "#/definitions/runtimeError"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "pair",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          },
+          {
+            "name": "since",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "format": "int64"
+          },
+          {
+            "name": "until",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "format": "int64"
+          },
+          {
+            "name": "bucket",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "enum": [
+              "DAY",
+              "HOUR",
+              "WEEK"
+            ],
+            "default": "DAY"
+          }
+        ],
+        "tags": [
+          "AnalyticsAPI"
+        ]
+      }
+    },
+    "/events/actions": {
+      "get": {
+        "operationId": "EventAPI_WatchActions",
+        "responses": {
+          "200": {
+            "description": "A successful response.(streaming responses)",
+            "schema": {
+              "type": "object",
+              "properties": {
+                "result": {
+                  "$ref": "#/definitions/protoActionEvent"
+                },
+                "error": {
+                  "$ref": "#/definitions/runtimeStreamError"
+                }
+              },
+              "title": "Stream result of protoActionEvent"
+            }
+          },
+          "default": {
+            "description": "An unexpected error response",
+            "schema": {
+              "$ref": "#/definitions/runtimeError"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "from_sequence",
+            "description": "resume after this sequence, 0 receives new events only.",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "format": "uint64"
+          }
+        ],
+        "tags": [
+          "EventAPI"
+        ]
+      }
+    },
+    "/events/chain": {
+      "get": {
+        "operationId": "EventAPI_WatchChainStatus",
+        "responses": {
+          "200": {
+            "description": "A successful response.(streaming responses)",
+            "schema": {
+              "type": "object",
+              "properties": {
+                "result": {
+                  "$ref": "#/definitions/protoChainStatusEvent"
+                },
+                "error": {
+                  "$ref": "#/definitions/runtimeStreamError"
+                }
+              },
+              "title": "Stream result of protoChainStatusEvent"
+            }
+          },
+          "default": {
+            "description": "An unexpected error response",
+            "schema": {
+              "$ref": "#/definitions/runtimeError"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "from_sequence",
+            "description": "resume after this sequence, 0 receives new events only.",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "format": "uint64"
+          }
+        ],
+        "tags": [
+          "EventAPI"
+        ]
+      }
+    },
+    "/events/quotes": {
+      "get": {
+        "summary": "WatchQuotes sends the quote each recorded swap was executed at.",
+        "operationId": "EventAPI_WatchQuotes",
+        "responses": {
+          "200": {
+            "description": "A successful response.(streaming responses)",
+            "schema": {
+              "type": "object",
+              "properties": {
+                "result": {
+                  "$ref": "#/definitions/protoQuoteEvent"
+                },
+                "error": {
+                  "$ref": "#/definitions/runtimeStreamError"
+                }
+              },
+              "title": "Stream result of protoQuoteEvent"
+            }
+          },
+          "default": {
+            "description": "An unexpected error response",
+            "schema": {
+              "$ref": "#/definitions/runtimeError"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "from_sequence",
+            "description": "resume after this sequence, 0 receives new events only.",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "format": "uint64"
+          }
+        ],
+        "tags": [
+          "EventAPI"
+        ]
+      }
+    },
+    "/events/transactions": {
+      "get": {
+        "summary": "WatchTransactions sends the actions whose transaction confirmed, failed or was\ndropped.",
+        "operationId": "EventAPI_WatchTransactions",
+        "responses": {
+          "200": {
+            "description": "A successful response.(streaming responses)",
+            "schema": {
+              "type": "object",
+              "properties": {
+                "result": {
+                  "$ref": "#/definitions/protoActionEvent"
+                },
+                "error": {
+                  "$ref": "#/definitions/runtimeStreamError"
+                }
+              },
+              "title": "Stream result of protoActionEvent"
+            }
+          },
+          "default": {
+            "description": "An unexpected error response",
+            "schema": {
+              "$ref": "#/definitions/runtimeError"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "from_sequence",
+            "description": "resume after this sequence, 0 receives new events only.",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "format": "uint64"
+          }
+        ],
+        "tags": [
+          "EventAPI"
+        ]
+      }
+    },
+    "/ping/info": {
+      "get": {
+        "operationId": "PingAPI_Info",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/protoString"
+            }
+          },
+          "default": {
+            "description": "An unexpected error response",
+            "schema": {
+              "$ref": "#/definitions/runtimeError"
+            }
+          }
+        },
+        "tags": [
+          "PingAPI"
+        ]
+      }
+    },
+    "/ping/status": {
+      "get": {
+        "operationId": "PingAPI_Status",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/protoBoolean"
+            }
+          },
+          "default": {
+            "description": "An unexpected error response",
+            "schema": {
+              "$ref": "#/definitions/runtimeError"
+            }
+          }
+        },
+        "tags": [
+          "PingAPI"
+        ]
+      }
+    }
+  },
+  "definitions": {
+    "protoAction": {
+      "type": "object",
+      "properties": {
+        "id": {
+          "type": "string",
+          "format": "uint64"
+        },
+        "pair": {
+          "type": "string"
+        },
+        "input": {
+          "type": "string",
+          "title": "amounts in tokens as exact decimals, e.g. 1.5"
+        },
+        "output": {
+          "type": "string"
+        },
+        "gas": {
+          "type": "string",
+          "format": "int64"
+        },
+        "price": {
+          "type": "string"
+        },
+        "profit": {
+          "type": "string",
+          "title": "profit in the output token, exact decimal"
+        },
+        "tx_hash": {
+          "type": "string"
+        },
+        "include_sake": {
+          "type": "boolean",
+          "format": "boolean"
+        },
+        "created_at": {
+          "type": "string",
+          "format": "int64",
+          "title": "unix milliseconds"
+        },
+        "input_units": {
+          "type": "string",
+          "title": "amounts in the smallest unit of the tokens"
+        },
+        "output_units": {
+          "type": "string"
+        },
+        "status": {
+          "type": "string",
+          "description": "status of the transaction: pending, confirmed, failed or dropped, unknown for\nswaps recorded before transactions were tracked. Empty without a transaction."
+        },
+        "confirmed_at": {
+          "type": "string",
+          "format": "int64",
+          "title": "unix milliseconds, 0 unless confirmed"
+        },
+        "gas_used": {
+          "type": "string",
+          "format": "int64",
+          "title": "gas of the confirmed transaction, gas is the estimate"
+        }
+      }
+    },
+    "protoActionEvent": {
+      "type": "object",
+      "properties": {
+        "sequence": {
+          "type": "string",
+          "format": "uint64"
+        },
+        "time": {
+          "type": "string",
+          "format": "int64",
+          "title": "unix milliseconds"
+        },
+        "action": {
+          "$ref": "#/definitions/protoAction"
+        }
+      }
+    },
+    "protoActionPage": {
+      "type": "object",
+      "properties": {
+        "actions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/protoAction"
+          }
+        },
+        "total": {
+          "type": "string",
+          "format": "int64",
+          "title": "actions of all pages"
+        },
+        "page": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "page_size": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "total_pages": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "next_cursor": {
+          "type": "string",
+          "description": "continues after this page, stable while actions are inserted. Empty on the last page."
+        }
+      }
+    },
+    "protoActionSort": {
+      "type": "string",
+      "enum": [
+        "TIME",
+        "PROFIT",
+        "GAS"
+      ],
+      "default": "TIME"
+    },
+    "protoBoolean": {
+      "type": "object",
+      "properties": {
+        "value": {
+          "type": "boolean",
+          "format": "boolean"
+        }
+      }
+    },
+    "protoChainStatus": {
+      "type": "object",
+      "properties": {
+        "online": {
+          "type": "boolean",
+          "format": "boolean"
+        },
+        "blocks": {
+          "type": "string",
+          "format": "uint64"
+        },
+        "error": {
+          "type": "string"
+        }
+      }
+    },
+    "protoChainStatusEvent": {
+      "type": "object",
+      "properties": {
+        "sequence": {
+          "type": "string",
+          "format": "uint64"
+        },
+        "time": {
+          "type": "string",
+          "format": "int64"
+        },
+        "status": {
+          "$ref": "#/definitions/protoChainStatus"
+        }
+      }
+    },
+    "protoPairStats": {
+      "type": "object",
+      "properties": {
+        "pair": {
+          "type": "string"
+        },
+        "stats": {
+          "$ref": "#/definitions/protoTradeStats"
+        }
+      }
+    },
+    "protoPairStatsResponse": {
+      "type": "object",
+      "properties": {
+        "pairs": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/protoPairStats"
+          }
+        },
+        "total": {
+          "$ref": "#/definitions/protoTradeStats",
+          "title": "stats of all selected actions"
+        }
+      }
+    },
+    "protoPnLPoint": {
+      "type": "object",
+      "properties": {
+        "time": {
+          "type": "string",
+          "format": "int64",
+          "description": "start of the bucket, unix milliseconds. Weeks start on monday, buckets are UTC."
+        },
+        "stats": {
+          "$ref": "#/definitions/protoTradeStats"
+        },
+        "cumulative_profit": {
+          "type": "string"
+        }
+      }
+    },
+    "protoPnLResponse": {
+      "type": "object",
+      "properties": {
+        "points": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/protoPnLPoint"
+          },
+          "title": "buckets with actions in order"
+        }
+      }
+    },
+    "protoQuote": {
+      "type": "object",
+      "properties": {
+        "pair": {
+          "type": "string"
+        },
+        "input": {
+          "type": "string"
+        },
+        "output": {
+          "type": "string"
+        },
+        "price": {
+          "type": "string"
+        },
+        "gas": {
+          "type": "string",
+          "format": "int64"
+        }
+      }
+    },
+    "protoQuoteEvent": {
+      "type": "object",
+      "properties": {
+        "sequence": {
+          "type": "string",
+          "format": "uint64"
+        },
+        "time": {
+          "type": "string",
+          "format": "int64"
+        },
+        "quote": {
+          "$ref": "#/definitions/protoQuote"
+        }
+      }
+    },
+    "protoString": {
+      "type": "object",
+      "properties": {
+        "value": {
+          "type": "string"
+        }
+      }
+    },
+    "protoTimeBucket": {
+      "type": "string",
+      "enum": [
+        "DAY",
+        "HOUR",
+        "WEEK"
+      ],
+      "default": "DAY"
+    },
+    "protoTradeStats": {
+      "type": "object",
+      "properties": {
+        "trades": {
+          "type": "string",
+          "format": "int64"
+        },
+        "total_profit": {
+          "type": "string"
+        },
+        "average_profit": {
+          "type": "string"
+        },
+        "median_profit": {
+          "type": "string"
+        },
+        "gas": {
+          "type": "string",
+          "format": "int64"
+        },
+        "win_rate": {
+          "type": "number",
+          "format": "double",
+          "title": "share of trades with a positive profit"
+        },
+        "best_profit": {
+          "type": "string"
+        },
+        "worst_profit": {
+          "type": "string"
+        }
+      },
+      "description": "Profits are exact decimals, the average is rounded to 18 fractional digits."
+    },
+    "protobufAny": {
+      "type": "object",
+      "properties": {
+        "type_url": {
+          "type": "string"
+        },
+        "value": {
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "runtimeError": {
+      "type": "object",
+      "properties": {
+        "error": {
+          "type": "string"
+        },
+        "code": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "message": {
+          "type": "string"
+        },
+        "details": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/protobufAny"
+          }
+        }
+      }
+    },
+    "runtimeStreamError": {
+      "type": "object",
+      "properties": {
+        "grpc_code": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "http_code": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "message": {
+          "type": "string"
+        },
+        "http_status": {
+          "type": "string"
+        },
+        "details": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/protobufAny"
+          }
+        }
+      }
+    }
+  }
+}
+`
@@ -0,0 +1,117 @@
+// Package swagger serves the OpenAPI document of the gateway APIs and an API explorer.
+package swagger
+
+//go:generate go run gen.go
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// SpecFile is the name of the OpenAPI document below the handler path.
+const SpecFile = "swagger.json"
+
+// Spec returns the OpenAPI document generated from the proto definitions.
+func Spec() []byte {
+	return []byte(spec)
+}
+
+// Handler serves the API explorer at prefix and the OpenAPI document at prefix/swagger.json.
+// The explorer is Swagger UI, its files are embedded by gen.go.
+func Handler(prefix string) http.Handler {
+	prefix = strings.TrimSuffix(prefix, "/") + "/"
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Path == strings.TrimSuffix(prefix, "/") {
+			http.Redirect(w, r, prefix, http.StatusMovedPermanently)
+			return
+		}
+		switch strings.TrimPrefix(r.URL.Path, prefix) {
+		case SpecFile:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(spec))
+		case "", "index.html":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(index))
+		default:
+			serveAsset(w, r, strings.TrimPrefix(r.URL.Path, prefix))
+		}
+	})
+}
+
+var contentTypes = map[string]string{
+	".js":  "application/javascript",
+	".css": "text/css; charset=utf-8",
+}
+
+// gzipped are the decoded assets.
+var gzipped = func() map[string][]byte {
+	files := make(map[string][]byte, len(assets))
+	for name, data := range assets {
+		gz, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			panic("swagger: asset " + name + ": " + err.Error())
+		}
+		files[name] = gz
+	}
+	return files
+}()
+
+// serveAsset serves a Swagger UI file, compressed to clients accepting gzip.
+func serveAsset(w http.ResponseWriter, r *http.Request, name string) {
+	gz, ok := gzipped[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", contentTypes[path.Ext(name)])
+	w.Header().Set("Vary", "Accept-Encoding")
+	if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(gz)
+		return
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	io.Copy(w, zr)
+}
+
+const index = `<!DOCTYPE html>
+<html>
+  <head>
+    <title>gbeyond API</title>
+    <meta charset="utf-8"/>
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <link rel="stylesheet" href="swagger-ui.css">
+    <style>
+      body {
+        margin: 0;
+        padding: 0;
+      }
+    </style>
+  </head>
+  <body>
+    <div id="swagger-ui"></div>
+    <script src="swagger-ui-bundle.js"> </script>
+    <script>
+      window.ui = SwaggerUIBundle({
+        url: "` + SpecFile + `",
+        dom_id: "#swagger-ui",
+        deepLinking: true
+      });
+    </script>
+  </body>
+</html>
+`
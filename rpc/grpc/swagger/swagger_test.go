@@ -0,0 +1,113 @@
+package swagger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSpecInSync(t *testing.T) {
+	source, err := ioutil.ReadFile("../proto/types.swagger.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(source, Spec()) {
+		t.Fatal("embedded spec is outdated, run go generate ./rpc/grpc/swagger")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	srv := httptest.NewServer(Handler("/swagger/"))
+	defer srv.Close()
+
+	for path, want := range map[string]string{
+		"/swagger/":             "text/html; charset=utf-8",
+		"/swagger/swagger.json": "application/json",
+		"/swagger":              "text/html; charset=utf-8",
+	} {
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != want {
+			t.Errorf("%s: status %d, content type %s", path, resp.StatusCode, resp.Header.Get("Content-Type"))
+		}
+		if want == "application/json" && !strings.Contains(string(body), `"swagger": "2.0"`) {
+			t.Errorf("%s: unexpected body %s", path, body)
+		}
+	}
+	resp, err := http.Get(srv.URL + "/swagger/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if strings.Contains(string(body), "://") {
+		t.Errorf("explorer loads remote files %s", body)
+	}
+	resp, err = http.Get(srv.URL + "/swagger/missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("missing file: status %d", resp.StatusCode)
+	}
+}
+
+// The embedded Swagger UI files match the ui folder, compressed or not.
+func TestAssets(t *testing.T) {
+	srv := httptest.NewServer(Handler("/swagger/"))
+	defer srv.Close()
+
+	for name, contentType := range map[string]string{
+		"swagger-ui-bundle.js": "application/javascript",
+		"swagger-ui.css":       "text/css; charset=utf-8",
+	} {
+		file, err := ioutil.ReadFile(filepath.Join("ui", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, encoding := range []string{"gzip", "identity"} {
+			req, err := http.NewRequest(http.MethodGet, srv.URL+"/swagger/"+name, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Accept-Encoding", encoding)
+			resp, err := http.DefaultTransport.RoundTrip(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var body []byte
+			if resp.Header.Get("Content-Encoding") == "gzip" {
+				zr, err := gzip.NewReader(resp.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				body, err = ioutil.ReadAll(zr)
+			} else {
+				body, err = ioutil.ReadAll(resp.Body)
+			}
+			resp.Body.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != contentType {
+				t.Errorf("%s %s: status %d, content type %s", name, encoding, resp.StatusCode, resp.Header.Get("Content-Type"))
+			}
+			if (resp.Header.Get("Content-Encoding") == "gzip") != (encoding == "gzip") {
+				t.Errorf("%s %s: content encoding %q", name, encoding, resp.Header.Get("Content-Encoding"))
+			}
+			if !bytes.Equal(body, file) {
+				t.Errorf("%s %s: embedded file is outdated, run go generate ./rpc/grpc/swagger", name, encoding)
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/drip/beyond/pkg/db"
+	jsonrpc2 "github.com/drip/beyond/pkg/jsonrpc2"
+	"github.com/drip/beyond/pkg/log"
+	"go.uber.org/zap"
+)
+
+// Session is a JSON-RPC connection together with the listener which accepted it.
+type Session struct {
+	Listener string `json:"listener"`
+	jsonrpc2.SessionInfo
+}
+
+// DatabaseStats are the statistics of the actions database.
+type DatabaseStats struct {
+	Driver string          `json:"driver"`
+	Pool   *db.PoolStats   `json:"pool"`
+	Writer *db.WriterStats `json:"writer"`
+}
+
+// AdminApi gives operators insight into the active JSON-RPC sessions and the database.
+type AdminApi struct {
+	servers func() map[string]*jsonrpc2.Server
+	writer  *db.Writer
+	backups *db.BackupScheduler
+	logger  *zap.SugaredLogger
+}
+
+// NewAdminApi creates the admin api, servers returns the running servers keyed by
+// listener name. writer and backups may be nil if there is no database or it isn't
+// backed up.
+func NewAdminApi(servers func() map[string]*jsonrpc2.Server, writer *db.Writer, backups *db.BackupScheduler) *AdminApi {
+	return &AdminApi{
+		servers: servers,
+		writer:  writer,
+		backups: backups,
+		logger:  log.NewLogger("api/admin"),
+	}
+}
+
+// Sessions lists the sessions of all listeners.
+func (a *AdminApi) Sessions() []*Session {
+	sessions := make([]*Session, 0)
+	for name, srv := range a.servers() {
+		for _, info := range srv.Sessions() {
+			sessions = append(sessions, &Session{Listener: name, SessionInfo: info})
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].ConnectedAt.Before(sessions[j].ConnectedAt)
+	})
+	return sessions
+}
+
+// Disconnect forcibly closes the session with the given id.
+func (a *AdminApi) Disconnect(id jsonrpc2.ID) (bool, error) {
+	for name, srv := range a.servers() {
+		if err := srv.Disconnect(id); err == nil {
+			a.logger.Infof("session %s on %s disconnected", id, name)
+			return true, nil
+		} else if err != jsonrpc2.ErrSessionNotFound {
+			return false, err
+		}
+	}
+	return false, jsonrpc2.ErrSessionNotFound
+}
+
+// Database returns the statistics of the connection pool and the writer of the database.
+func (a *AdminApi) Database() (*DatabaseStats, error) {
+	if a.writer == nil {
+		return nil, errors.New("no database")
+	}
+	pool, err := db.ConnPoolStats(a.writer.DB())
+	if err != nil {
+		return nil, err
+	}
+	return &DatabaseStats{
+		Driver: a.writer.DB().Dialector.Name(),
+		Pool:   pool,
+		Writer: a.writer.Stats(),
+	}, nil
+}
+
+// Backup backs the database up now and returns the backup.
+func (a *AdminApi) Backup(ctx context.Context) (*db.BackupInfo, error) {
+	if a.backups == nil {
+		return nil, errors.New("no database backups")
+	}
+	return a.backups.Backup(ctx)
+}
+
+// Backups lists the backups of the database, the latest first.
+func (a *AdminApi) Backups() ([]*db.BackupInfo, error) {
+	if a.backups == nil {
+		return nil, errors.New("no database backups")
+	}
+	backups, err := a.backups.Backups()
+	if err != nil {
+		return nil, err
+	}
+	if backups == nil {
+		backups = make([]*db.BackupInfo, 0)
+	}
+	return backups, nil
+}
@@ -1,18 +1,42 @@
 package jsonrpc
 
 import (
+	"github.com/drip/beyond/pkg/db"
 	"github.com/drip/beyond/rpc/jsonrpc/api"
 	jsonrpc2 "github.com/drip/beyond/pkg/jsonrpc2"
 )
 
+// RegisterApis adds APIs served by other components, e.g. the gRPC services. It must be
+// called before StartRPC.
+func (r *RPC) RegisterApis(apis ...jsonrpc2.API) {
+	r.extApis = append(r.extApis, apis...)
+}
+
+// SetDatabase sets the writer of the actions database, whose stats the admin api reports.
+// It must be called before StartRPC.
+func (r *RPC) SetDatabase(writer *db.Writer) {
+	r.writer = writer
+}
+
+// SetBackups sets the backups of the actions database, which the admin api triggers. It
+// must be called before StartRPC.
+func (r *RPC) SetBackups(backups *db.BackupScheduler) {
+	r.backups = backups
+}
+
 func (r *RPC) getApi(apiModule string) jsonrpc2.API {
+	for _, api := range r.extApis {
+		if api.Namespace == apiModule {
+			return api
+		}
+	}
 	switch apiModule {
-	case "ping":
+	case "admin":
 		return jsonrpc2.API{
-			Namespace: "ping",
+			Namespace: "admin",
 			Version:   "1.0",
-			Service:   api.NewPingApi(r.config),
-			Public:    true,
+			Service:   api.NewAdminApi(r.servers, r.writer, r.backups),
+			Public:    false,
 		}
 	default:
 		return jsonrpc2.API{}
@@ -34,7 +58,7 @@ func (r *RPC) GetInProcessApis() []jsonrpc2.API {
 
 //Ipc apis
 func (r *RPC) GetIpcApis() []jsonrpc2.API {
-	return r.GetPublicApis()
+	return append(r.GetPublicApis(), r.GetApis("admin")...)
 }
 
 //Http apis
@@ -48,6 +72,11 @@ func (r *RPC) GetWSApis() []jsonrpc2.API {
 }
 
 func (r *RPC) GetPublicApis() []jsonrpc2.API {
-	apiModules := []string{"ping"}
-	return r.GetApis(apiModules...)
+	var apis []jsonrpc2.API
+	for _, api := range r.extApis {
+		if api.Public {
+			apis = append(apis, api)
+		}
+	}
+	return apis
 }
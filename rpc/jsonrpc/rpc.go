@@ -1,11 +1,13 @@
 package jsonrpc
 
 import (
+	"context"
 	"errors"
 	"github.com/drip/beyond/config"
+	"github.com/drip/beyond/pkg/db"
 	"github.com/drip/beyond/pkg/log"
 	"net"
-	"net/url"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -14,21 +16,40 @@ import (
 	"go.uber.org/zap"
 )
 
+const stopTimeout = 5 * time.Second
+
+var httpTimeouts = jsonrpc2.HTTPTimeouts{
+	ReadTimeout:  30 * time.Second,
+	WriteTimeout: 200 * time.Second,
+	IdleTimeout:  200 * time.Second,
+}
+
+// endpoint is a running HTTP or WebSocket listener.
+type endpoint struct {
+	name     string
+	url      string
+	listener net.Listener
+	handler  *jsonrpc2.Server
+}
+
 type RPC struct {
 	rpcAPIs          []jsonrpc2.API
+	extApis          []jsonrpc2.API
 	inProcessHandler *jsonrpc2.Server
 
 	ipcListener net.Listener
 	ipcHandler  *jsonrpc2.Server
 
-	httpWhitelist []string
-	httpListener  net.Listener
-	httpHandler   *jsonrpc2.Server
+	httpEndpoints     []*endpoint
+	wsEndpoints       []*endpoint
+	combinedEndpoints []*endpoint
 
-	wsListener net.Listener
-	wsHandler  *jsonrpc2.Server
+	gatewayHandler     *jsonrpc2.Server
+	gatewayHTTPHandler http.Handler
 
-	config *config.Config
+	config  *config.Config
+	writer  *db.Writer
+	backups *db.BackupScheduler
 
 	lock   sync.RWMutex
 	logger *zap.SugaredLogger
@@ -66,74 +87,272 @@ func (r *RPC) stopIPC() {
 		r.logger.Debug("IPC endpoint closed, ", "endpoint:", r.config.RPCCfg.IPCEndpoint)
 	}
 	if r.ipcHandler != nil {
-		r.ipcHandler.Stop()
+		r.shutdown("IPC", r.ipcHandler)
 		r.ipcHandler = nil
 	}
 }
 
-// startHTTP initializes and starts the HTTP RPC endpoint.
-func (r *RPC) startHTTP(endpoint string, apis []jsonrpc2.API, modules []string, cors []string, vhosts []string, timeouts jsonrpc2.HTTPTimeouts, exposeAll bool) error {
-	// Short circuit if the HTTP endpoint isn't being exposed
-	if endpoint == "" {
+// startFunc opens a listener serving apis.
+type startFunc func(endpoint string, apis []jsonrpc2.API, cfg *jsonrpc2.EndpointConfig) (net.Listener, *jsonrpc2.Server, error)
+
+// startEndpoint starts the listener of lc and records it in endpoints.
+func (r *RPC) startEndpoint(kind string, start startFunc, lc *config.ListenerCfg, apis []jsonrpc2.API, endpoints *[]*endpoint) error {
+	// Short circuit if the endpoint isn't being exposed
+	if lc.Endpoint == "" {
 		return nil
 	}
-	listener, handler, err := jsonrpc2.StartHTTPEndpoint(endpoint, apis, modules, cors, vhosts, timeouts)
+	cfg, err := r.endpointConfig(lc)
 	if err != nil {
 		return err
 	}
-	r.logger.Info("HTTP endpoint opened,", " url:", listener.Addr(), ", cors:", strings.Join(cors, ","), ", vhosts:", strings.Join(vhosts, ","))
-	// All listeners booted successfully
-	//r.httpEndpoint = endpoint
-	r.httpListener = listener
-	r.httpHandler = handler
+	listener, handler, err := start(lc.Endpoint, apis, cfg)
+	if err != nil {
+		return err
+	}
+	r.logger.Info(kind, " endpoint opened, ", "name:", lc.Name, ", url:", listener.Addr(), lc.Path, ", cors:", strings.Join(cfg.Cors, ","), ", vhosts:", strings.Join(cfg.Vhosts, ","))
+	r.lock.Lock()
+	*endpoints = append(*endpoints, &endpoint{name: lc.Name, url: lc.Endpoint, listener: listener, handler: handler})
+	r.lock.Unlock()
 
 	return nil
 }
 
-// stopHTTP terminates the HTTP RPC endpoint.
+// stopEndpoints closes the listeners in endpoints and drains their servers.
+func (r *RPC) stopEndpoints(kind string, endpoints *[]*endpoint) {
+	r.lock.Lock()
+	stopped := *endpoints
+	*endpoints = nil
+	r.lock.Unlock()
+
+	for _, e := range stopped {
+		e.listener.Close()
+		r.logger.Debug(kind, " endpoint closed, ", "name:", e.name, ", endpoint:", e.url)
+		r.shutdown(e.name, e.handler)
+	}
+}
+
+// startHTTP initializes and starts an HTTP RPC endpoint.
+func (r *RPC) startHTTP(lc *config.ListenerCfg, apis []jsonrpc2.API) error {
+	return r.startEndpoint("HTTP", jsonrpc2.StartHTTPEndpoint, lc, apis, &r.httpEndpoints)
+}
+
+// stopHTTP terminates the HTTP RPC endpoints.
 func (r *RPC) stopHTTP() {
-	if r.httpListener != nil {
-		r.httpListener.Close()
-		r.httpListener = nil
+	r.stopEndpoints("HTTP", &r.httpEndpoints)
+}
+
+// startWS initializes and starts a websocket RPC endpoint.
+func (r *RPC) startWS(lc *config.ListenerCfg, apis []jsonrpc2.API) error {
+	return r.startEndpoint("WebSocket", jsonrpc2.StartWSEndpoint, lc, apis, &r.wsEndpoints)
+}
+
+// stopWS terminates the websocket RPC endpoints.
+func (r *RPC) stopWS() {
+	r.stopEndpoints("WebSocket", &r.wsEndpoints)
+}
+
+// startCombined initializes and starts an endpoint serving HTTP and websocket clients.
+func (r *RPC) startCombined(lc *config.ListenerCfg, apis []jsonrpc2.API) error {
+	return r.startEndpoint("HTTP/WebSocket", jsonrpc2.StartCombinedEndpoint, lc, apis, &r.combinedEndpoints)
+}
 
-		r.logger.Debug("HTTP endpoint closed, ", "endpoint:", r.config.RPCCfg.HTTPEndpoint)
+// stopCombined terminates the endpoints serving HTTP and websocket clients.
+func (r *RPC) stopCombined() {
+	r.stopEndpoints("HTTP/WebSocket", &r.combinedEndpoints)
+}
+
+// startGateway creates the server mounted on the gRPC gateway.
+func (r *RPC) startGateway(lc *config.ListenerCfg, apis []jsonrpc2.API) error {
+	cfg, err := r.endpointConfig(lc)
+	if err != nil {
+		return err
 	}
-	if r.httpHandler != nil {
-		r.httpHandler.Stop()
-		r.httpHandler = nil
+	handler, err := jsonrpc2.NewEndpointServer(apis, cfg)
+	if err != nil {
+		return err
 	}
+	r.lock.Lock()
+	r.gatewayHandler = handler
+	r.gatewayHTTPHandler = handler.CombinedHandler(cfg)
+	r.lock.Unlock()
+	r.logger.Info("Gateway endpoint created, ", "path:", lc.Path)
+	return nil
 }
 
-// startWS initializes and starts the websocket RPC endpoint.
-func (r *RPC) startWS(endpoint string, apis []jsonrpc2.API, modules []string, wsOrigins []string, exposeAll bool) error {
-	// Short circuit if the WS endpoint isn't being exposed
-	if endpoint == "" {
-		return nil
+// stopGateway drains the server mounted on the gRPC gateway.
+func (r *RPC) stopGateway() {
+	r.lock.Lock()
+	handler := r.gatewayHandler
+	r.gatewayHandler = nil
+	r.gatewayHTTPHandler = nil
+	r.lock.Unlock()
+
+	if handler != nil {
+		r.shutdown("gateway", handler)
+	}
+}
+
+// GatewayHandler returns the handler to mount on the gRPC gateway under path, or nil if
+// JSON-RPC isn't served on the gateway.
+func (r *RPC) GatewayHandler() (path string, handler http.Handler) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if r.gatewayHTTPHandler == nil {
+		return "", nil
 	}
-	listener, handler, err := jsonrpc2.StartWSEndpoint(endpoint, apis, modules, wsOrigins, exposeAll)
+	return r.config.RPCCfg.Gateway.Path, r.gatewayHTTPHandler
+}
+
+// shared reports whether the httpEndpoint and webSocketEndpoint are served by one
+// combined listener.
+func (r *RPC) shared() bool {
+	cfg := r.config.RPCCfg
+	return cfg.HTTPEnabled && cfg.WSEnabled && cfg.HTTPEndpoint != "" && cfg.HTTPEndpoint == cfg.WSEndpoint
+}
+
+// httpListeners returns the configured HTTP listeners, the one of the httpEndpoint
+// setting first.
+func (r *RPC) httpListeners() []*config.ListenerCfg {
+	cfg := r.config.RPCCfg
+	var listeners []*config.ListenerCfg
+	if !r.shared() {
+		listeners = append(listeners, &config.ListenerCfg{
+			Name:         "http",
+			Endpoint:     cfg.HTTPEndpoint,
+			Cors:         cfg.HTTPCors,
+			VirtualHosts: cfg.HttpVirtualHosts,
+		})
+	}
+	return append(listeners, named("http", cfg.HTTPListeners)...)
+}
+
+// wsListeners returns the configured WebSocket listeners, the one of the
+// webSocketEndpoint setting first.
+func (r *RPC) wsListeners() []*config.ListenerCfg {
+	cfg := r.config.RPCCfg
+	var listeners []*config.ListenerCfg
+	if !r.shared() {
+		listeners = append(listeners, &config.ListenerCfg{
+			Name:     "ws",
+			Endpoint: cfg.WSEndpoint,
+			Cors:     []string{},
+		})
+	}
+	return append(listeners, named("ws", cfg.WSListeners)...)
+}
+
+// combinedListeners returns the configured listeners serving both HTTP and WebSocket
+// clients, including the httpEndpoint if it is shared with the webSocketEndpoint.
+func (r *RPC) combinedListeners() []*config.ListenerCfg {
+	cfg := r.config.RPCCfg
+	var listeners []*config.ListenerCfg
+	if r.shared() {
+		listeners = append(listeners, &config.ListenerCfg{
+			Name:         "http",
+			Endpoint:     cfg.HTTPEndpoint,
+			Cors:         cfg.HTTPCors,
+			VirtualHosts: cfg.HttpVirtualHosts,
+		})
+	}
+	return append(listeners, named("rpc", cfg.Listeners)...)
+}
+
+// named returns the listeners with unnamed ones named by config.ListenerName.
+func named(prefix string, listeners []*config.ListenerCfg) []*config.ListenerCfg {
+	result := make([]*config.ListenerCfg, 0, len(listeners))
+	for i, lc := range listeners {
+		if lc.Name == "" {
+			l := *lc
+			l.Name = config.ListenerName(prefix, i, lc)
+			lc = &l
+		}
+		result = append(result, lc)
+	}
+	return result
+}
+
+// listenerApis returns the APIs for a listener, the public ones unless the listener
+// whitelists modules.
+func (r *RPC) listenerApis(lc *config.ListenerCfg, public func() []jsonrpc2.API) []jsonrpc2.API {
+	if len(lc.Modules) == 0 {
+		return public()
+	}
+	return r.GetApis(lc.Modules...)
+}
+
+// endpointConfig converts the listener settings, unset socket permissions are taken
+// from the transport settings.
+func (r *RPC) endpointConfig(lc *config.ListenerCfg) (*jsonrpc2.EndpointConfig, error) {
+	socket := lc.Socket
+	if socket == nil {
+		socket = r.config.RPCCfg.Socket
+	}
+	sockOpts, err := socket.Options()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	tlsCfg, err := lc.TLS.Config()
+	if err != nil {
+		return nil, err
 	}
-	r.logger.Info("WebSocket endpoint opened, ", "url:", listener.Addr())
-	// All listeners booted successfully
-	//r.wsEndpoint = endpoint
-	r.wsListener = listener
-	r.wsHandler = handler
+	cfg := &jsonrpc2.EndpointConfig{
+		Modules:  lc.Modules,
+		Cors:     lc.Cors,
+		Vhosts:   lc.VirtualHosts,
+		Timeouts: httpTimeouts,
+		TLS:      tlsCfg,
+		Socket:   sockOpts,
+		Prefix:   lc.Path,
+	}
+	if lc.Auth != nil {
+		cfg.Auth = &jsonrpc2.AuthConfig{Tokens: lc.Auth.Tokens, Users: lc.Auth.Users}
+	}
+	return cfg, nil
+}
 
-	return nil
+// servers returns the running JSON-RPC servers keyed by listener name.
+func (r *RPC) servers() map[string]*jsonrpc2.Server {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	servers := make(map[string]*jsonrpc2.Server)
+	if r.inProcessHandler != nil {
+		servers["inproc"] = r.inProcessHandler
+	}
+	if r.ipcHandler != nil {
+		servers["ipc"] = r.ipcHandler
+	}
+	for _, e := range r.httpEndpoints {
+		servers[e.name] = e.handler
+	}
+	for _, e := range r.wsEndpoints {
+		servers[e.name] = e.handler
+	}
+	for _, e := range r.combinedEndpoints {
+		servers[e.name] = e.handler
+	}
+	if r.gatewayHandler != nil {
+		servers["gateway"] = r.gatewayHandler
+	}
+	return servers
 }
 
-// stopWS terminates the websocket RPC endpoint.
-func (r *RPC) stopWS() {
-	if r.wsListener != nil {
-		r.wsListener.Close()
-		r.wsListener = nil
-		r.logger.Debug("WebSocket endpoint closed, ", "endpoint:", r.config.RPCCfg.WSEndpoint)
+// Endpoints returns the addresses of the running listeners by name.
+func (r *RPC) Endpoints() map[string]net.Addr {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	endpoints := make(map[string]net.Addr)
+	if r.ipcListener != nil {
+		endpoints["ipc"] = r.ipcListener.Addr()
 	}
-	if r.wsHandler != nil {
-		r.wsHandler.Stop()
-		r.wsHandler = nil
+	for _, list := range [][]*endpoint{r.httpEndpoints, r.wsEndpoints, r.combinedEndpoints} {
+		for _, e := range list {
+			endpoints[e.name] = e.listener.Addr()
+		}
 	}
+	return endpoints
 }
 
 func (r *RPC) Attach() (*jsonrpc2.Client, error) {
@@ -168,11 +387,19 @@ func (r *RPC) startInProcess(apis []jsonrpc2.API) error {
 // stopInProc terminates the in-process RPC endpoint.
 func (r *RPC) stopInProcess() {
 	if r.inProcessHandler != nil {
-		r.inProcessHandler.Stop()
+		r.shutdown("InProc", r.inProcessHandler)
 		r.inProcessHandler = nil
 	}
 }
 
+// shutdown drains srv, waiting up to stopTimeout for pending calls to finish.
+func (r *RPC) shutdown(name string, srv *jsonrpc2.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+	defer cancel()
+	report := srv.Shutdown(ctx)
+	r.logger.Info(name, " endpoint drained, sessions: ", report.Sessions, ", subscriptions: ", report.Subscriptions, ", aborted calls: ", report.Aborted)
+}
+
 func (r *RPC) StopRPC() {
 	r.stopInProcess()
 	if r.config.RPCCfg.Enable && r.config.RPCCfg.IPCEnabled {
@@ -184,6 +411,10 @@ func (r *RPC) StopRPC() {
 	if r.config.RPCCfg.Enable && r.config.RPCCfg.WSEnabled {
 		r.stopWS()
 	}
+	if r.config.RPCCfg.Enable {
+		r.stopCombined()
+		r.stopGateway()
+	}
 
 }
 
@@ -207,37 +438,51 @@ func (r *RPC) StartRPC() error {
 	}
 
 	if r.config.RPCCfg.Enable && r.config.RPCCfg.HTTPEnabled {
-		apis := r.GetHttpApis()
-		timeout := jsonrpc2.HTTPTimeouts{
-			ReadTimeout:  30 * time.Second,
-			WriteTimeout: 200 * time.Second,
-			IdleTimeout:  200 * time.Second,
-		}
-		if err := r.startHTTP(r.config.RPCCfg.HTTPEndpoint, apis, nil, r.config.RPCCfg.HTTPCors, r.config.RPCCfg.HttpVirtualHosts, timeout, false); err != nil {
-			r.logger.Info(err)
-			r.stopInProcess()
-			r.stopIPC()
-			return err
+		for _, lc := range r.httpListeners() {
+			if err := r.startHTTP(lc, r.listenerApis(lc, r.GetHttpApis)); err != nil {
+				r.logger.Info(err)
+				r.stopInProcess()
+				r.stopIPC()
+				r.stopHTTP()
+				return err
+			}
 		}
 	}
 
 	if r.config.RPCCfg.Enable && r.config.RPCCfg.WSEnabled {
-		apis := r.GetWSApis()
-		if err := r.startWS(r.config.RPCCfg.WSEndpoint, apis, nil, []string{}, false); err != nil {
-			r.logger.Info(err)
-			//r.stopInProcess()
-			r.stopIPC()
-			r.stopHTTP()
-			return err
+		for _, lc := range r.wsListeners() {
+			if err := r.startWS(lc, r.listenerApis(lc, r.GetWSApis)); err != nil {
+				r.logger.Info(err)
+				//r.stopInProcess()
+				r.stopIPC()
+				r.stopHTTP()
+				r.stopWS()
+				return err
+			}
 		}
 	}
-	return nil
-}
 
-func scheme(endpoint string) (string, string, error) {
-	u, err := url.Parse(endpoint)
-	if err != nil {
-		return "", "", err
+	if r.config.RPCCfg.Enable {
+		for _, lc := range r.combinedListeners() {
+			if err := r.startCombined(lc, r.listenerApis(lc, r.GetHttpApis)); err != nil {
+				r.logger.Info(err)
+				r.stopIPC()
+				r.stopHTTP()
+				r.stopWS()
+				r.stopCombined()
+				return err
+			}
+		}
+		if lc := r.config.RPCCfg.Gateway; lc != nil {
+			if err := r.startGateway(lc, r.listenerApis(lc, r.GetHttpApis)); err != nil {
+				r.logger.Info(err)
+				r.stopIPC()
+				r.stopHTTP()
+				r.stopWS()
+				r.stopCombined()
+				return err
+			}
+		}
 	}
-	return u.Scheme, u.Host, nil
+	return nil
 }
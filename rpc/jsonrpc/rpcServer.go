@@ -2,8 +2,12 @@ package jsonrpc
 
 import (
 	"github.com/drip/beyond/config"
+	"github.com/drip/beyond/pkg/db"
+	jsonrpc2 "github.com/drip/beyond/pkg/jsonrpc2"
 	"github.com/drip/beyond/pkg/log"
 	"go.uber.org/zap"
+	"net"
+	"net/http"
 )
 
 type RPCService struct {
@@ -26,6 +30,32 @@ func (r *RPCService) Start() error {
 	return r.rpc.StartRPC()
 }
 
+// RegisterApis adds APIs served by other components, it must be called before Start.
+func (r *RPCService) RegisterApis(apis ...jsonrpc2.API) {
+	r.rpc.RegisterApis(apis...)
+}
+
+// SetDatabase sets the writer of the actions database, it must be called before Start.
+func (r *RPCService) SetDatabase(writer *db.Writer) {
+	r.rpc.SetDatabase(writer)
+}
+
+// SetBackups sets the backups of the actions database, it must be called before Start.
+func (r *RPCService) SetBackups(backups *db.BackupScheduler) {
+	r.rpc.SetBackups(backups)
+}
+
+// GatewayHandler returns the handler to mount on the gRPC gateway under path, or nil if
+// JSON-RPC isn't served on the gateway. It is available once the service is started.
+func (r *RPCService) GatewayHandler() (path string, handler http.Handler) {
+	return r.rpc.GatewayHandler()
+}
+
+// Endpoints returns the addresses of the running listeners by name.
+func (r *RPCService) Endpoints() map[string]net.Addr {
+	return r.rpc.Endpoints()
+}
+
 func (r *RPCService) Stop() {
 	r.rpc.StopRPC()
 	r.logger.Info("wrapper grpc stopped")